@@ -45,6 +45,7 @@ func main() {
 
 	// Initialize feed store
 	feedStore := feed.NewStore(database.Pool())
+	feedStore.SetDeletionPolicy(feed.DeletionPolicy(cfg.DeletedCommentPolicy))
 	log.Println("Feed store initialized")
 
 	// Initialize feed ingester
@@ -67,11 +68,34 @@ func main() {
 	ingester.Run(ctx)
 	log.Println("Feed ingester started")
 
+	// Start live event broker (Postgres LISTEN → SSE subscribers)
+	broker := feed.NewBroker(database.Pool(), feedStore)
+	broker.Run(ctx)
+	log.Println("Feed broker started")
+
+	// Keep the relevance-sorted feed current
+	relevanceRefresher := feed.NewRelevanceRefresher(feedStore, cfg.RelevanceRefreshInterval)
+	relevanceRefresher.Run(ctx)
+	log.Println("Relevance refresher started")
+
+	// Fetch GitHub profiles (account age etc.) for voters
+	userEnricher := feed.NewUserEnricher(githubClient, feedStore, cfg.UserEnrichInterval, cfg.UserProfileTTL)
+	userEnricher.Run(ctx)
+	log.Println("User enricher started")
+
+	// Recompute voting blocs for /analytics/blocs
+	blocAnalyzer := feed.NewBlocAnalyzer(feedStore, cfg.BlocAnalysisInterval)
+	blocAnalyzer.Run(ctx)
+	log.Println("Bloc analyzer started")
+
 	// Create router
 	routerResult := api.NewRouter(&api.RouterConfig{
 		Database:  database,
 		FeedStore: feedStore,
 		Ingester:  ingester,
+		Broker:    broker,
+
+		WebhookSecret: cfg.GitHubWebhookSecret,
 	})
 
 	// Create server
@@ -102,6 +126,22 @@ func main() {
 	log.Println("Stopping feed ingester...")
 	ingester.Stop()
 
+	// Stop relevance refresher
+	log.Println("Stopping relevance refresher...")
+	relevanceRefresher.Stop()
+
+	// Stop user enricher
+	log.Println("Stopping user enricher...")
+	userEnricher.Stop()
+
+	// Stop bloc analyzer
+	log.Println("Stopping bloc analyzer...")
+	blocAnalyzer.Stop()
+
+	// Close live streams so Shutdown doesn't wait on open SSE connections
+	log.Println("Stopping feed broker...")
+	broker.Stop()
+
 	// Stop rate limiter cleanup goroutines
 	log.Println("Stopping rate limiters...")
 	routerResult.RateLimiters.Stop()
@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/csv"
 	"encoding/json"
+	"encoding/xml"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
@@ -19,13 +21,15 @@ import (
 type FeedHandler struct {
 	store    *feed.Store
 	ingester *feed.Ingester
+	broker   *feed.Broker
 }
 
 // NewFeedHandler creates a new feed handler
-func NewFeedHandler(store *feed.Store, ingester *feed.Ingester) *FeedHandler {
+func NewFeedHandler(store *feed.Store, ingester *feed.Ingester, broker *feed.Broker) *FeedHandler {
 	return &FeedHandler{
 		store:    store,
 		ingester: ingester,
+		broker:   broker,
 	}
 }
 
@@ -35,6 +39,7 @@ type FeedHealthResponse struct {
 	LastEventAt    *string                 `json:"lastEventAt,omitempty"`
 	EventsLastHour int                     `json:"eventsLastHour"`
 	Ingesters      map[string]IngesterInfo `json:"ingesters"`
+	Gaps           []*feed.Gap             `json:"gaps,omitempty"` // Recent Events API gaps, newest first
 }
 
 // IngesterInfo represents ingester status
@@ -53,7 +58,7 @@ func (h *FeedHandler) Health(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Get last event time
-	stats, err := h.store.GetStats(ctx)
+	stats, err := h.store.GetStats(ctx, nil)
 	if err == nil && stats.LatestEventAt != nil {
 		timeStr := stats.LatestEventAt.Format(time.RFC3339)
 		response.LastEventAt = &timeStr
@@ -75,6 +80,24 @@ func (h *FeedHandler) Health(w http.ResponseWriter, r *http.Request) {
 			LastPoll: status.DiscussionsLastPoll.Format(time.RFC3339),
 			Status:   status.DiscussionsStatus,
 		}
+		if !status.WebhooksLastDelivery.IsZero() {
+			response.Ingesters["webhooks"] = IngesterInfo{
+				LastPoll: status.WebhooksLastDelivery.Format(time.RFC3339),
+				Status:   status.WebhooksStatus,
+			}
+		}
+	}
+
+	// Recent Events API gaps; any still unfilled means the feed has holes
+	gaps, err := h.store.ListGaps(ctx, false, 10)
+	if err == nil {
+		response.Gaps = gaps
+		for _, gap := range gaps {
+			if gap.FilledAt == nil {
+				response.Status = "degraded"
+				break
+			}
+		}
 	}
 
 	respondJSON(w, http.StatusOK, response)
@@ -108,6 +131,7 @@ func (h *FeedHandler) List(w http.ResponseWriter, r *http.Request) {
 	// Build filters
 	filters := &feed.ListFilters{
 		ExcludeCommentReactions: true, // Hide comment reactions from feed (show inline on comments instead)
+		IncludeDeleted:          r.URL.Query().Get("includeDeleted") == "true",
 	}
 
 	if typeFilter != "" {
@@ -157,6 +181,10 @@ func (h *FeedHandler) List(w http.ResponseWriter, r *http.Request) {
 
 	// Query events
 	events, err := h.store.List(ctx, filters, sort, limit, cursorPtr)
+	if errors.Is(err, feed.ErrInvalidCursor) {
+		http.Error(w, "Invalid cursor", http.StatusBadRequest)
+		return
+	}
 	if err != nil {
 		slog.Error("Failed to fetch events", "error", err)
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
@@ -166,8 +194,13 @@ func (h *FeedHandler) List(w http.ResponseWriter, r *http.Request) {
 	// Attach inline reaction summaries to comment events
 	attachReactionSummaries(ctx, h.store, events)
 
-	// Get total count
-	totalCount, err := h.store.Count(ctx, filters)
+	// Get total count, of the 30-day view when that's what is paged
+	var totalCount int
+	if sort == "relevance" {
+		totalCount, err = h.store.CountByRelevance(ctx, filters)
+	} else {
+		totalCount, err = h.store.Count(ctx, filters)
+	}
 	if err != nil {
 		totalCount = 0 // Non-critical, continue
 	}
@@ -175,8 +208,8 @@ func (h *FeedHandler) List(w http.ResponseWriter, r *http.Request) {
 	// Generate next cursor if we got a full page
 	var nextCursor *string
 	if len(events) == limit && len(events) > 0 {
-		lastEvent := events[len(events)-1]
-		nextCursor = &lastEvent.ID
+		next := feed.NextCursor(sort, events[len(events)-1])
+		nextCursor = &next
 	}
 
 	response := ListResponse{
@@ -188,6 +221,158 @@ func (h *FeedHandler) List(w http.ResponseWriter, r *http.Request) {
 	respondJSON(w, http.StatusOK, response)
 }
 
+// streamHeartbeat keeps idle SSE connections alive through proxies
+const streamHeartbeat = 25 * time.Second
+
+// streamReplayPage and streamReplayMax bound Last-Event-ID replay. A client
+// further behind than streamReplayMax gets a reset event and should refetch.
+const (
+	streamReplayPage = 500
+	streamReplayMax  = 5000
+)
+
+// Stream handles GET /api/feed/stream
+// Pushes newly inserted events as Server-Sent Events. Supports the same
+// type, pr and user filters as List. On reconnect, Last-Event-ID (or the
+// lastEventId query param) replays events ingested after that event.
+func (h *FeedHandler) Stream(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if h.broker == nil {
+		http.Error(w, "Streaming unavailable", http.StatusServiceUnavailable)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	typeFilter := r.URL.Query().Get("type")
+	prStr := r.URL.Query().Get("pr")
+	userFilter := r.URL.Query().Get("user")
+
+	filters := &feed.ListFilters{
+		ExcludeCommentReactions: true, // Same as List: comment reactions show inline
+	}
+	if typeFilter != "" {
+		for _, t := range strings.Split(typeFilter, ",") {
+			t = strings.TrimSpace(t)
+			if t != "" {
+				filters.Types = append(filters.Types, feed.EventType(t))
+			}
+		}
+	}
+	if prStr != "" {
+		if pr, err := strconv.Atoi(prStr); err == nil {
+			filters.PRNumber = &pr
+		}
+	}
+	if userFilter != "" {
+		filters.GitHubUser = &userFilter
+	}
+
+	lastEventID := r.Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = r.URL.Query().Get("lastEventId")
+	}
+
+	// Resolve the user filter to an account before the broker shares filters
+	if err := h.store.ResolveUser(r.Context(), filters); err != nil {
+		slog.Error("Failed to resolve stream user filter", "user", userFilter, "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	// Subscribe before replaying so nothing inserted in between is missed
+	sub, err := h.broker.Subscribe(filters)
+	if err != nil {
+		w.Header().Set("Retry-After", "30")
+		http.Error(w, "Stream capacity full, try again shortly", http.StatusServiceUnavailable)
+		return
+	}
+	defer h.broker.Unsubscribe(sub)
+
+	// Long-lived response: lift the server-wide write timeout for this request
+	http.NewResponseController(w).SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprint(w, "retry: 5000\n\n")
+	flusher.Flush()
+
+	replayed := map[string]bool{}
+	for cursor := lastEventID; cursor != ""; {
+		if len(replayed) >= streamReplayMax {
+			// Too far behind to replay: tell the client to refetch the feed
+			slog.Info("Stream replay limit reached, sending reset", "last_event_id", lastEventID)
+			if _, err := fmt.Fprint(w, "event: reset\ndata: {}\n\n"); err != nil {
+				return
+			}
+			break
+		}
+
+		missed, err := h.store.ListIngestedAfter(ctx, filters, cursor, streamReplayPage)
+		if err != nil {
+			slog.Debug("Stream replay skipped", "last_event_id", cursor, "error", err)
+			break
+		}
+		for _, event := range missed {
+			if err := writeSSE(w, event); err != nil {
+				return
+			}
+			replayed[event.ID] = true
+		}
+		flusher.Flush()
+
+		if len(missed) < streamReplayPage {
+			break // Caught up
+		}
+		cursor = missed[len(missed)-1].ID
+	}
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(streamHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case event, ok := <-sub.Events:
+			if !ok {
+				return // Dropped as slow or broker stopping; client reconnects
+			}
+			if replayed[event.ID] {
+				continue
+			}
+			if err := writeSSE(w, event); err != nil {
+				return
+			}
+			flusher.Flush()
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": keepalive\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// writeSSE writes one event as an SSE message with its ID for resumption
+func writeSSE(w http.ResponseWriter, event *feed.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %s\ndata: %s\n\n", event.ID, data)
+	return err
+}
+
 // StatsResponse represents feed statistics
 type StatsResponse struct {
 	TotalEvents    int                `json:"totalEvents"`
@@ -196,13 +381,21 @@ type StatsResponse struct {
 	LatestEventAt  *time.Time         `json:"latestEventAt,omitempty"`
 	EventsByType   map[string]int     `json:"eventsByType"`
 	EventsLastHour int                `json:"eventsLastHour"`
+	AsOf           *time.Time         `json:"asOf,omitempty"`
 }
 
 // Stats handles GET /api/feed/stats
+// Optional asOf (RFC3339) and asOfIngested=true, see parsePointInTime.
 func (h *FeedHandler) Stats(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	stats, err := h.store.GetStats(ctx)
+	asOf, err := parsePointInTime(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	stats, err := h.store.GetStats(ctx, asOf)
 	if err != nil {
 		slog.Error("Failed to fetch stats", "error", err)
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
@@ -217,6 +410,9 @@ func (h *FeedHandler) Stats(w http.ResponseWriter, r *http.Request) {
 		EventsByType:   stats.EventsByType,
 		EventsLastHour: stats.EventsLastHour,
 	}
+	if asOf != nil {
+		response.AsOf = &asOf.At
+	}
 
 	respondJSON(w, http.StatusOK, response)
 }
@@ -304,10 +500,33 @@ func (h *FeedHandler) GetByUser(w http.ResponseWriter, r *http.Request) {
 
 // GetVoters handles GET /api/feed/voters
 // This is the CRITICAL endpoint for TU Delft Sybil research
+// Optional asOf (RFC3339) and asOfIngested=true, see parsePointInTime.
+// Optional minAccountAgeDays, maxAccountAgeDays and accountType=User|Bot
+// filter by GitHub profile; voters not yet enriched are then excluded.
 func (h *FeedHandler) GetVoters(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	voters, err := h.store.GetVoters(ctx)
+	asOf, err := parsePointInTime(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	filters := &feed.VoterFilters{Type: r.URL.Query().Get("accountType")}
+	if filters.Type != "" && filters.Type != "User" && filters.Type != "Bot" {
+		http.Error(w, "Invalid accountType", http.StatusBadRequest)
+		return
+	}
+	if filters.MinAccountAgeDays, err = parseDays(r, "minAccountAgeDays"); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if filters.MaxAccountAgeDays, err = parseDays(r, "maxAccountAgeDays"); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	voters, err := h.store.GetVoters(ctx, asOf, filters)
 	if err != nil {
 		slog.Error("Failed to fetch voters", "error", err)
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
@@ -318,6 +537,7 @@ func (h *FeedHandler) GetVoters(w http.ResponseWriter, r *http.Request) {
 }
 
 // GetVoter handles GET /api/feed/voters/{username}
+// Optional asOf (RFC3339) and asOfIngested=true, see parsePointInTime.
 func (h *FeedHandler) GetVoter(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	username := chi.URLParam(r, "username")
@@ -327,7 +547,13 @@ func (h *FeedHandler) GetVoter(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	voter, err := h.store.GetVoter(ctx, username)
+	asOf, err := parsePointInTime(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	voter, err := h.store.GetVoter(ctx, username, asOf)
 	if err != nil {
 		http.Error(w, "Voter not found", http.StatusNotFound)
 		return
@@ -336,6 +562,91 @@ func (h *FeedHandler) GetVoter(w http.ResponseWriter, r *http.Request) {
 	respondJSON(w, http.StatusOK, voter)
 }
 
+// SimilarVotersResponse lists the voters who vote most like a given voter
+type SimilarVotersResponse struct {
+	GitHubUser string                  `json:"githubUser"`
+	MinOverlap int                     `json:"minOverlap"`
+	Similar    []*feed.VoterSimilarity `json:"similar"`
+}
+
+// GetSimilarVoters handles GET /api/feed/voters/{username}/similar
+// Ranks other voters by agreement rate over co-voted PRs (last vote wins).
+// Optional limit (default 20, max 100) and minOverlap (default 3). 404 for
+// unknown voters.
+func (h *FeedHandler) GetSimilarVoters(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	username := chi.URLParam(r, "username")
+
+	if username == "" || len(username) > 39 {
+		http.Error(w, "Invalid username", http.StatusBadRequest)
+		return
+	}
+
+	limit := 20
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 100 {
+			limit = l
+		}
+	}
+	minOverlap := 3
+	if overlapStr := r.URL.Query().Get("minOverlap"); overlapStr != "" {
+		o, err := strconv.Atoi(overlapStr)
+		if err != nil || o < 1 || o > 10000 {
+			http.Error(w, "Invalid minOverlap", http.StatusBadRequest)
+			return
+		}
+		minOverlap = o
+	}
+
+	similar, err := h.store.GetSimilarVoters(ctx, username, minOverlap, limit)
+	if errors.Is(err, feed.ErrVoterNotFound) {
+		http.Error(w, "Voter not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		slog.Error("Failed to fetch similar voters", "user", username, "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, SimilarVotersResponse{
+		GitHubUser: username,
+		MinOverlap: minOverlap,
+		Similar:    similar,
+	})
+}
+
+// BlocsResponse lists the voting blocs from the latest analysis
+type BlocsResponse struct {
+	Blocs        []*feed.VotingBloc `json:"blocs"`
+	MinOverlap   int                `json:"minOverlap"`
+	MinAgreement float64            `json:"minAgreement"`
+	ComputedAt   *time.Time         `json:"computedAt,omitempty"` // Omitted when no blocs were found yet
+}
+
+// GetVotingBlocs handles GET /api/feed/analytics/blocs
+// Served from the cache the bloc analyzer refreshes every BLOC_ANALYSIS_INTERVAL.
+func (h *FeedHandler) GetVotingBlocs(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	blocs, err := h.store.ListVotingBlocs(ctx)
+	if err != nil {
+		slog.Error("Failed to fetch voting blocs", "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	response := BlocsResponse{
+		Blocs:        blocs,
+		MinOverlap:   feed.BlocMinOverlap,
+		MinAgreement: feed.BlocMinAgreement,
+	}
+	if len(blocs) > 0 {
+		response.ComputedAt = &blocs[0].ComputedAt
+	}
+	respondJSON(w, http.StatusOK, response)
+}
+
 // PRVotesResponse represents vote breakdown for a PR
 type PRVotesResponse struct {
 	PRNumber  int                `json:"prNumber"`
@@ -343,16 +654,42 @@ type PRVotesResponse struct {
 	Downvotes int                `json:"downvotes"`
 	Net       int                `json:"net"`
 	Voters    []VoterVoteDetails `json:"voters"`
+	AsOf      *time.Time         `json:"asOf,omitempty"`
+
+	// Tallies split by the voters' relationship to the PR
+	ByRelationship  map[string]*VoteTally `json:"byRelationship"`
+	ExcludingAuthor VoteTally             `json:"excludingAuthor"`
+}
+
+// VoteTally is an up/down vote count
+type VoteTally struct {
+	Upvotes   int `json:"upvotes"`
+	Downvotes int `json:"downvotes"`
+	Net       int `json:"net"`
+}
+
+// add counts one vote
+func (t *VoteTally) add(choice int8) {
+	switch choice {
+	case 1:
+		t.Upvotes++
+	case -1:
+		t.Downvotes++
+	}
+	t.Net = t.Upvotes - t.Downvotes
 }
 
 // VoterVoteDetails represents individual voter details for a PR
 type VoterVoteDetails struct {
-	GitHubUser string    `json:"githubUser"`
-	Choice     int8      `json:"choice"` // +1 or -1
-	VotedAt    time.Time `json:"votedAt"`
+	GitHubUser   string    `json:"githubUser"`
+	Choice       int8      `json:"choice"` // +1 or -1
+	VotedAt      time.Time `json:"votedAt"`
+	Relationship string    `json:"relationship"` // author, reviewer, commenter or none
 }
 
 // GetPRVotes handles GET /api/feed/votes/pr/{number}
+// Optional asOf (RFC3339) and asOfIngested=true, see parsePointInTime, or
+// atMerge=true for the tally at the moment the PR was merged.
 func (h *FeedHandler) GetPRVotes(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	numberStr := chi.URLParam(r, "number")
@@ -363,8 +700,32 @@ func (h *FeedHandler) GetPRVotes(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	asOf, err := parsePointInTime(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if r.URL.Query().Get("atMerge") == "true" {
+		if asOf != nil {
+			http.Error(w, "atMerge and asOf are mutually exclusive", http.StatusBadRequest)
+			return
+		}
+		mergedAt, err := h.store.GetPRMergedAt(ctx, number)
+		if err != nil {
+			slog.Error("Failed to fetch PR merge time", "pr", number, "error", err)
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+		if mergedAt == nil {
+			http.Error(w, "PR not merged", http.StatusNotFound)
+			return
+		}
+		asOf = &feed.PointInTime{At: *mergedAt, Ingested: r.URL.Query().Get("asOfIngested") == "true"}
+	}
+
 	// Get vote breakdown
-	upvotes, downvotes, err := h.store.GetPRVotes(ctx, number)
+	upvotes, downvotes, err := h.store.GetPRVotes(ctx, number, asOf)
 	if err != nil {
 		slog.Error("Failed to fetch PR votes", "pr", number, "error", err)
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
@@ -372,7 +733,7 @@ func (h *FeedHandler) GetPRVotes(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Get detailed voter list
-	voteDetails, err := h.store.GetPRVoteDetails(ctx, number)
+	voteDetails, err := h.store.GetPRVoteDetails(ctx, number, asOf)
 	if err != nil {
 		slog.Error("Failed to fetch vote details", "pr", number, "error", err)
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
@@ -381,28 +742,330 @@ func (h *FeedHandler) GetPRVotes(w http.ResponseWriter, r *http.Request) {
 
 	// Convert to response format
 	voters := make([]VoterVoteDetails, len(voteDetails))
+	byRelationship := map[string]*VoteTally{
+		feed.RelationAuthor:    {},
+		feed.RelationReviewer:  {},
+		feed.RelationCommenter: {},
+		feed.RelationNone:      {},
+	}
+	var excludingAuthor VoteTally
 	for i, detail := range voteDetails {
 		voters[i] = VoterVoteDetails{
-			GitHubUser: detail.GitHubUser,
-			Choice:     detail.Choice,
-			VotedAt:    detail.OccurredAt,
+			GitHubUser:   detail.GitHubUser,
+			Choice:       detail.Choice,
+			VotedAt:      detail.OccurredAt,
+			Relationship: detail.Relationship,
+		}
+		byRelationship[detail.Relationship].add(detail.Choice)
+		if detail.Relationship != feed.RelationAuthor {
+			excludingAuthor.add(detail.Choice)
 		}
 	}
 
 	response := PRVotesResponse{
-		PRNumber:  number,
-		Upvotes:   upvotes,
-		Downvotes: downvotes,
-		Net:       upvotes - downvotes,
-		Voters:    voters,
+		PRNumber:        number,
+		Upvotes:         upvotes,
+		Downvotes:       downvotes,
+		Net:             upvotes - downvotes,
+		Voters:          voters,
+		ByRelationship:  byRelationship,
+		ExcludingAuthor: excludingAuthor,
+	}
+	if asOf != nil {
+		response.AsOf = &asOf.At
 	}
 
 	respondJSON(w, http.StatusOK, response)
 }
 
+// GetPRVoteTimeline handles GET /api/feed/votes/pr/{number}/timeline
+// Cumulative tally over time with lifecycle markers. Optional
+// bucket=event|hour|day (default event).
+func (h *FeedHandler) GetPRVoteTimeline(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	numberStr := chi.URLParam(r, "number")
+
+	number, err := strconv.Atoi(numberStr)
+	if err != nil || number < 1 || number > 1000000 {
+		http.Error(w, "Invalid PR number", http.StatusBadRequest)
+		return
+	}
+
+	bucket := r.URL.Query().Get("bucket")
+	switch bucket {
+	case "":
+		bucket = feed.BucketEvent
+	case feed.BucketEvent, feed.BucketHour, feed.BucketDay:
+	default:
+		http.Error(w, "Invalid bucket", http.StatusBadRequest)
+		return
+	}
+
+	timeline, err := h.store.GetPRVoteTimeline(ctx, number, bucket)
+	if err != nil {
+		slog.Error("Failed to fetch PR vote timeline", "pr", number, "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, timeline)
+}
+
+// PRVoteChangesResponse lists each voter's vote history on a PR
+type PRVoteChangesResponse struct {
+	PRNumber      int                 `json:"prNumber"`
+	TotalVoters   int                 `json:"totalVoters"`
+	ChangedVoters int                 `json:"changedVoters"` // Flipped, held both or retracted
+	Voters        []*feed.VoteHistory `json:"voters"`
+}
+
+// GetPRVoteChanges handles GET /api/feed/votes/pr/{number}/changes
+// Every vote reaction (and detected removal) per user in order, with flip
+// counts. changedOnly=true omits voters who cast a single vote and kept it.
+func (h *FeedHandler) GetPRVoteChanges(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	numberStr := chi.URLParam(r, "number")
+
+	number, err := strconv.Atoi(numberStr)
+	if err != nil || number < 1 || number > 1000000 {
+		http.Error(w, "Invalid PR number", http.StatusBadRequest)
+		return
+	}
+
+	histories, err := h.store.GetPRVoteChanges(ctx, number)
+	if err != nil {
+		slog.Error("Failed to fetch PR vote changes", "pr", number, "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	response := PRVoteChangesResponse{
+		PRNumber:    number,
+		TotalVoters: len(histories),
+		Voters:      []*feed.VoteHistory{},
+	}
+	changedOnly := r.URL.Query().Get("changedOnly") == "true"
+	for _, history := range histories {
+		if len(history.Changes) > 1 {
+			response.ChangedVoters++
+		} else if changedOnly {
+			continue
+		}
+		response.Voters = append(response.Voters, history)
+	}
+
+	respondJSON(w, http.StatusOK, response)
+}
+
+// PRVoteFlagsResponse lists a PR's current votes with advisory flags
+type PRVoteFlagsResponse struct {
+	PRNumber     int                 `json:"prNumber"`
+	TotalVotes   int                 `json:"totalVotes"`
+	FlaggedVotes int                 `json:"flaggedVotes"`
+	ByReason     map[string]int      `json:"byReason"`
+	Votes        []*feed.FlaggedVote `json:"votes"`
+}
+
+// GetPRVoteFlags handles GET /api/feed/votes/pr/{number}/flags
+// Annotates each current vote with the brigading heuristics it trips.
+// Flags are advisory and don't affect tallies. flaggedOnly=true omits
+// votes without flags.
+func (h *FeedHandler) GetPRVoteFlags(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	numberStr := chi.URLParam(r, "number")
+
+	number, err := strconv.Atoi(numberStr)
+	if err != nil || number < 1 || number > 1000000 {
+		http.Error(w, "Invalid PR number", http.StatusBadRequest)
+		return
+	}
+
+	votes, err := h.store.GetPRVoteFlags(ctx, number)
+	if err != nil {
+		slog.Error("Failed to fetch PR vote flags", "pr", number, "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	response := PRVoteFlagsResponse{
+		PRNumber:   number,
+		TotalVotes: len(votes),
+		ByReason:   make(map[string]int),
+		Votes:      []*feed.FlaggedVote{},
+	}
+	flaggedOnly := r.URL.Query().Get("flaggedOnly") == "true"
+	for _, vote := range votes {
+		if len(vote.Flags) > 0 {
+			response.FlaggedVotes++
+			for _, flag := range vote.Flags {
+				response.ByReason[flag.Reason]++
+			}
+		} else if flaggedOnly {
+			continue
+		}
+		response.Votes = append(response.Votes, vote)
+	}
+
+	respondJSON(w, http.StatusOK, response)
+}
+
+// LeaderboardResponse represents a page of the PR leaderboard
+type LeaderboardResponse struct {
+	PRs        []*feed.PRTally `json:"prs"`
+	NextCursor *string         `json:"nextCursor,omitempty"`
+}
+
+// GetPRLeaderboard handles GET /api/feed/votes/prs
+// Ranks PRs by net votes ("last vote wins", as GetPRVotes). Optional
+// state=open|closed|merged, since/until (RFC3339) to count only votes cast in
+// that window, limit and cursor.
+func (h *FeedHandler) GetPRLeaderboard(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	filters := feed.LeaderboardFilters{State: r.URL.Query().Get("state")}
+	switch filters.State {
+	case "", "open", "closed", "merged":
+	default:
+		http.Error(w, "Invalid state", http.StatusBadRequest)
+		return
+	}
+
+	if sinceStr := r.URL.Query().Get("since"); sinceStr != "" {
+		since, err := time.Parse(time.RFC3339, sinceStr)
+		if err != nil {
+			http.Error(w, "Invalid since", http.StatusBadRequest)
+			return
+		}
+		filters.Since = &since
+	}
+
+	if untilStr := r.URL.Query().Get("until"); untilStr != "" {
+		until, err := time.Parse(time.RFC3339, untilStr)
+		if err != nil {
+			http.Error(w, "Invalid until", http.StatusBadRequest)
+			return
+		}
+		filters.Until = &until
+	}
+
+	limit := 50
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 100 {
+			limit = l
+		}
+	}
+
+	cursor := r.URL.Query().Get("cursor")
+	if cursor != "" {
+		if _, _, ok := feed.ParseLeaderboardCursor(cursor); !ok {
+			http.Error(w, "Invalid cursor", http.StatusBadRequest)
+			return
+		}
+	}
+
+	tallies, err := h.store.ListPRLeaderboard(ctx, filters, limit, cursor)
+	if err != nil {
+		slog.Error("Failed to fetch PR leaderboard", "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	var nextCursor *string
+	if len(tallies) == limit {
+		next := feed.LeaderboardCursor(tallies[len(tallies)-1])
+		nextCursor = &next
+	}
+
+	respondJSON(w, http.StatusOK, LeaderboardResponse{PRs: tallies, NextCursor: nextCursor})
+}
+
+// PRListResponse represents a page of PRs with their current state
+type PRListResponse struct {
+	PRs        []*feed.PullRequest `json:"prs"`
+	NextCursor *string             `json:"nextCursor,omitempty"`
+}
+
+// PRResponse represents a PR's current state with its vote tally
+type PRResponse struct {
+	*feed.PullRequest
+	Net int `json:"net"`
+}
+
+// ListPRs handles GET /api/feed/prs
+// Lists PRs newest first with vote tallies. Optional state=open|closed|merged;
+// cursor is the nextCursor of the previous page.
+func (h *FeedHandler) ListPRs(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	state := r.URL.Query().Get("state")
+	switch state {
+	case "", "open", "closed", "merged":
+	default:
+		http.Error(w, "Invalid state", http.StatusBadRequest)
+		return
+	}
+
+	limit := 50
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 100 {
+			limit = l
+		}
+	}
+
+	cursor := 0
+	if cursorStr := r.URL.Query().Get("cursor"); cursorStr != "" {
+		c, err := strconv.Atoi(cursorStr)
+		if err != nil || c < 1 {
+			http.Error(w, "Invalid cursor", http.StatusBadRequest)
+			return
+		}
+		cursor = c
+	}
+
+	prs, err := h.store.ListPRs(ctx, state, limit, cursor)
+	if err != nil {
+		slog.Error("Failed to fetch PRs", "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	var nextCursor *string
+	if len(prs) == limit {
+		next := strconv.Itoa(prs[len(prs)-1].Number)
+		nextCursor = &next
+	}
+
+	respondJSON(w, http.StatusOK, PRListResponse{PRs: prs, NextCursor: nextCursor})
+}
+
+// GetPR handles GET /api/feed/prs/{number}
+func (h *FeedHandler) GetPR(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	numberStr := chi.URLParam(r, "number")
+
+	number, err := strconv.Atoi(numberStr)
+	if err != nil || number < 1 || number > 1000000 {
+		http.Error(w, "Invalid PR number", http.StatusBadRequest)
+		return
+	}
+
+	pr, err := h.store.GetPR(ctx, number)
+	if errors.Is(err, feed.ErrPRNotFound) {
+		http.Error(w, "PR not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		slog.Error("Failed to fetch PR", "pr", number, "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, PRResponse{PullRequest: pr, Net: pr.Upvotes - pr.Downvotes})
+}
+
 // Export handles GET /api/feed/export
 // Bulk export for researchers — streams all events as NDJSON or CSV.
-// Supports the same filters as List: type, pr, user, since, until, sort.
+// Supports the same filters as List: type, pr, user, since, until, sort, includeDeleted.
 // Uses cursor pagination internally with 1000-event pages.
 // Protected by: strict rate limit (2/min/IP), concurrency cap (3 global), 30s timeout.
 func (h *FeedHandler) Export(w http.ResponseWriter, r *http.Request) {
@@ -429,7 +1092,9 @@ func (h *FeedHandler) Export(w http.ResponseWriter, r *http.Request) {
 	sinceStr := r.URL.Query().Get("since")
 	untilStr := r.URL.Query().Get("until")
 
-	filters := &feed.ListFilters{}
+	filters := &feed.ListFilters{
+		IncludeDeleted: r.URL.Query().Get("includeDeleted") == "true",
+	}
 	if typeFilter != "" {
 		for _, t := range strings.Split(typeFilter, ",") {
 			t = strings.TrimSpace(t)
@@ -543,8 +1208,8 @@ func (h *FeedHandler) Export(w http.ResponseWriter, r *http.Request) {
 		}
 
 		// Set cursor for next page
-		lastID := events[len(events)-1].ID
-		cursor = &lastID
+		next := feed.NextCursor(sort, events[len(events)-1])
+		cursor = &next
 
 		if len(events) < 1000 {
 			break // Last page
@@ -556,6 +1221,280 @@ func (h *FeedHandler) Export(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// ExportGraph streams the interaction graph (votes, comments and reviews
+// pointing at the author of the PR/issue/discussion) as GraphML, GEXF or an
+// edge-list CSV for trust research.
+// GET /api/feed/export/graph?format=graphml|gexf|csv&since=&until=&kinds=vote,comment,review
+func (h *FeedHandler) ExportGraph(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
+	defer cancel()
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "graphml"
+	}
+	if format != "graphml" && format != "gexf" && format != "csv" {
+		http.Error(w, "Invalid format (use graphml, gexf or csv)", http.StatusBadRequest)
+		return
+	}
+
+	filters := feed.GraphFilters{}
+	if kindsStr := r.URL.Query().Get("kinds"); kindsStr != "" {
+		for _, k := range strings.Split(kindsStr, ",") {
+			k = strings.TrimSpace(k)
+			if k != feed.EdgeVote && k != feed.EdgeComment && k != feed.EdgeReview {
+				http.Error(w, "Invalid kinds (use vote, comment, review)", http.StatusBadRequest)
+				return
+			}
+			filters.Kinds = append(filters.Kinds, k)
+		}
+	}
+	if sinceStr := r.URL.Query().Get("since"); sinceStr != "" {
+		since, err := time.Parse(time.RFC3339, sinceStr)
+		if err != nil {
+			http.Error(w, "Invalid since: must be RFC3339", http.StatusBadRequest)
+			return
+		}
+		filters.Since = &since
+	}
+	if untilStr := r.URL.Query().Get("until"); untilStr != "" {
+		until, err := time.Parse(time.RFC3339, untilStr)
+		if err != nil {
+			http.Error(w, "Invalid until: must be RFC3339", http.StatusBadRequest)
+			return
+		}
+		filters.Until = &until
+	}
+
+	maxExport := 100000 // Safety cap
+
+	switch format {
+	case "csv":
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.Header().Set("Content-Disposition", "attachment; filename=openchaos-feed-graph.csv")
+	case "gexf":
+		w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+		w.Header().Set("Content-Disposition", "attachment; filename=openchaos-feed-graph.gexf")
+	default:
+		w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+		w.Header().Set("Content-Disposition", "attachment; filename=openchaos-feed-graph.graphml")
+	}
+	w.Header().Set("Trailer", "X-Export-Truncated")
+	w.WriteHeader(http.StatusOK)
+
+	gw := &graphWriter{ctx: ctx, w: w, nodes: make(map[string]bool)}
+	stream := func(passes ...func(*feed.GraphEdge) error) error {
+		return h.store.StreamInteractionGraph(ctx, filters, maxExport, passes...)
+	}
+	var err error
+	switch format {
+	case "csv":
+		err = gw.writeCSV(stream)
+	case "gexf":
+		err = gw.writeGEXF(stream)
+	default:
+		err = gw.writeGraphML(stream)
+	}
+	switch {
+	case errors.Is(err, feed.ErrGraphTruncated):
+		slog.Warn("Graph export truncated", "max", maxExport)
+		w.Header().Set("X-Export-Truncated", "true")
+	case gw.err != nil:
+		slog.Info("Export write error (client likely disconnected)", "format", format, "edges_written", gw.edges, "error", gw.err)
+	case err != nil:
+		slog.Error("Graph export query failed", "format", format, "edges_written", gw.edges, "error", err)
+	}
+}
+
+// graphStream reads the interaction graph once per pass (see
+// feed.Store.StreamInteractionGraph)
+type graphStream func(passes ...func(*feed.GraphEdge) error) error
+
+// graphFlushEvery is how many nodes/edges are written between flushes
+const graphFlushEvery = 1000
+
+// graphWriter writes an interaction graph as its edges are read, flushing
+// periodically and stopping at the first write error (or timeout)
+type graphWriter struct {
+	ctx     context.Context
+	w       http.ResponseWriter
+	err     error
+	written int             // Nodes and edges written
+	edges   int             // Edges written, for edge IDs
+	nodes   map[string]bool // Node IDs written
+}
+
+// printf writes formatted output unless a previous write failed
+func (g *graphWriter) printf(format string, args ...interface{}) {
+	if g.err != nil {
+		return
+	}
+	_, g.err = fmt.Fprintf(g.w, format, args...)
+}
+
+// element counts a written node/edge, flushing every graphFlushEvery and
+// aborting once the context expires. The returned error stops the stream.
+func (g *graphWriter) element() error {
+	g.written++
+	if g.written%graphFlushEvery == 0 {
+		g.flush()
+	}
+	if g.err == nil && g.ctx.Err() != nil {
+		g.err = g.ctx.Err()
+	}
+	return g.err
+}
+
+func (g *graphWriter) flush() {
+	if f, ok := g.w.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// newNode reports whether the node hasn't been written yet, marking it written
+func (g *graphWriter) newNode(id string) bool {
+	if g.nodes[id] {
+		return false
+	}
+	g.nodes[id] = true
+	return true
+}
+
+// writeCSV writes one row per edge
+func (g *graphWriter) writeCSV(stream graphStream) error {
+	csvWriter := csv.NewWriter(g.w)
+	csvWriter.Write([]string{
+		"source", "source_login", "target", "target_login",
+		"kind", "weight", "count", "first_at", "last_at",
+	})
+	return stream(func(e *feed.GraphEdge) error {
+		csvWriter.Write([]string{
+			feed.GraphNodeID(e.SourceID, e.SourceLogin), e.SourceLogin,
+			feed.GraphNodeID(e.TargetID, e.TargetLogin), e.TargetLogin,
+			e.Kind,
+			strconv.Itoa(e.Weight),
+			strconv.Itoa(e.Count),
+			e.FirstAt.Format(time.RFC3339),
+			e.LastAt.Format(time.RFC3339),
+		})
+		csvWriter.Flush()
+		if g.err = csvWriter.Error(); g.err != nil {
+			return g.err
+		}
+		g.edges++
+		return g.element()
+	})
+}
+
+// writeGraphML writes a GraphML document with edge kind/weight/count/time data
+// keys. GraphML allows nodes and edges in any order, so each node is written
+// just before the first edge that uses it.
+func (g *graphWriter) writeGraphML(stream graphStream) error {
+	g.printf(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	g.printf(`<graphml xmlns="http://graphml.graphdrawing.org/xmlns">` + "\n")
+	g.printf(`  <key id="label" for="node" attr.name="label" attr.type="string"/>` + "\n")
+	g.printf(`  <key id="kind" for="edge" attr.name="kind" attr.type="string"/>` + "\n")
+	g.printf(`  <key id="weight" for="edge" attr.name="weight" attr.type="int"/>` + "\n")
+	g.printf(`  <key id="count" for="edge" attr.name="count" attr.type="int"/>` + "\n")
+	g.printf(`  <key id="first_at" for="edge" attr.name="first_at" attr.type="string"/>` + "\n")
+	g.printf(`  <key id="last_at" for="edge" attr.name="last_at" attr.type="string"/>` + "\n")
+	g.printf(`  <graph id="openchaos" edgedefault="directed">` + "\n")
+
+	node := func(id int64, login string) error {
+		key := feed.GraphNodeID(id, login)
+		if !g.newNode(key) {
+			return nil
+		}
+		g.printf(`    <node id="%s"><data key="label">%s</data></node>`+"\n", xmlEscape(key), xmlEscape(login))
+		return g.element()
+	}
+	err := stream(func(e *feed.GraphEdge) error {
+		if err := node(e.SourceID, e.SourceLogin); err != nil {
+			return err
+		}
+		if err := node(e.TargetID, e.TargetLogin); err != nil {
+			return err
+		}
+		g.printf(`    <edge id="e%d" source="%s" target="%s">`, g.edges,
+			xmlEscape(feed.GraphNodeID(e.SourceID, e.SourceLogin)), xmlEscape(feed.GraphNodeID(e.TargetID, e.TargetLogin)))
+		g.printf(`<data key="kind">%s</data><data key="weight">%d</data><data key="count">%d</data>`, e.Kind, e.Weight, e.Count)
+		g.printf(`<data key="first_at">%s</data><data key="last_at">%s</data></edge>`+"\n",
+			e.FirstAt.Format(time.RFC3339), e.LastAt.Format(time.RFC3339))
+		g.edges++
+		return g.element()
+	})
+	if err != nil && !errors.Is(err, feed.ErrGraphTruncated) {
+		return err
+	}
+
+	g.printf("  </graph>\n</graphml>\n")
+	return err
+}
+
+// writeGEXF writes a GEXF 1.3 document; edge kind, count and times are
+// attributes. GEXF lists every node before any edge, so the graph is read
+// twice: once for nodes, once for edges.
+func (g *graphWriter) writeGEXF(stream graphStream) error {
+	g.printf(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	g.printf(`<gexf xmlns="http://gexf.net/1.3" version="1.3">` + "\n")
+	g.printf(`  <graph defaultedgetype="directed" mode="static">` + "\n")
+	g.printf(`    <attributes class="edge">` + "\n")
+	g.printf(`      <attribute id="kind" title="kind" type="string"/>` + "\n")
+	g.printf(`      <attribute id="count" title="count" type="integer"/>` + "\n")
+	g.printf(`      <attribute id="first_at" title="first_at" type="string"/>` + "\n")
+	g.printf(`      <attribute id="last_at" title="last_at" type="string"/>` + "\n")
+	g.printf(`    </attributes>` + "\n")
+	g.printf("    <nodes>\n")
+
+	node := func(id int64, login string) error {
+		key := feed.GraphNodeID(id, login)
+		if !g.newNode(key) {
+			return nil
+		}
+		g.printf(`      <node id="%s" label="%s"/>`+"\n", xmlEscape(key), xmlEscape(login))
+		return g.element()
+	}
+	edgesOpen := false
+	openEdges := func() {
+		if !edgesOpen {
+			edgesOpen = true
+			g.printf("    </nodes>\n    <edges>\n")
+		}
+	}
+	err := stream(
+		func(e *feed.GraphEdge) error {
+			if err := node(e.SourceID, e.SourceLogin); err != nil {
+				return err
+			}
+			return node(e.TargetID, e.TargetLogin)
+		},
+		func(e *feed.GraphEdge) error {
+			openEdges()
+			g.printf(`      <edge id="e%d" source="%s" target="%s" weight="%d">`, g.edges,
+				xmlEscape(feed.GraphNodeID(e.SourceID, e.SourceLogin)), xmlEscape(feed.GraphNodeID(e.TargetID, e.TargetLogin)), e.Weight)
+			g.printf(`<attvalues><attvalue for="kind" value="%s"/><attvalue for="count" value="%d"/>`, e.Kind, e.Count)
+			g.printf(`<attvalue for="first_at" value="%s"/><attvalue for="last_at" value="%s"/></attvalues></edge>`+"\n",
+				e.FirstAt.Format(time.RFC3339), e.LastAt.Format(time.RFC3339))
+			g.edges++
+			return g.element()
+		},
+	)
+	if err != nil && !errors.Is(err, feed.ErrGraphTruncated) {
+		return err
+	}
+
+	openEdges()
+	g.printf("    </edges>\n  </graph>\n</gexf>\n")
+	return err
+}
+
+// xmlEscape escapes s for use in XML text and attribute values
+func xmlEscape(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
 // commentEventTypes are event types that represent comments (not reactions).
 // These are candidates for inline reaction summaries.
 var commentEventTypes = map[feed.EventType]bool{
@@ -627,6 +1566,38 @@ func attachReactionSummaries(ctx context.Context, store *feed.Store, events []*f
 	}
 }
 
+// parsePointInTime reads the asOf (RFC3339) and asOfIngested=true query
+// params shared by the vote and stats endpoints. Returns nil without asOf.
+func parsePointInTime(r *http.Request) (*feed.PointInTime, error) {
+	asOfStr := r.URL.Query().Get("asOf")
+	ingested := r.URL.Query().Get("asOfIngested") == "true"
+	if asOfStr == "" {
+		if ingested && r.URL.Query().Get("atMerge") != "true" {
+			return nil, fmt.Errorf("asOfIngested requires asOf")
+		}
+		return nil, nil
+	}
+
+	at, err := time.Parse(time.RFC3339, asOfStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid asOf: must be RFC3339")
+	}
+	return &feed.PointInTime{At: at, Ingested: ingested}, nil
+}
+
+// parseDays reads an optional non-negative day count query param
+func parseDays(r *http.Request, param string) (*int, error) {
+	value := r.URL.Query().Get(param)
+	if value == "" {
+		return nil, nil
+	}
+	days, err := strconv.Atoi(value)
+	if err != nil || days < 0 || days > 36500 {
+		return nil, fmt.Errorf("invalid %s", param)
+	}
+	return &days, nil
+}
+
 func intPtrStr(p *int) string {
 	if p == nil {
 		return ""
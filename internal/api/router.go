@@ -13,6 +13,10 @@ type RouterConfig struct {
 	Database interface{ Health(context.Context) error }
 	FeedStore *feed.Store
 	Ingester  *feed.Ingester
+	Broker    *feed.Broker
+
+	// WebhookSecret enables POST /api/webhooks/github when non-empty
+	WebhookSecret string
 }
 
 // RouterResult holds the router and resources that need cleanup
@@ -35,34 +39,56 @@ func NewRouter(cfg *RouterConfig) *RouterResult {
 	r.Use(LoggingMiddleware)
 	r.Use(middleware.Recoverer)
 	r.Use(CORSMiddleware)
-	r.Use(rateLimiters.Global.Middleware)
 
-	// Health endpoint
-	if cfg.Database != nil {
-		r.Get("/api/health", NewHealthHandler(cfg.Database))
-	} else {
-		r.Get("/api/health", HealthHandler)
-	}
+	// Public API: per-IP rate limited
+	r.Group(func(r chi.Router) {
+		r.Use(rateLimiters.Global.Middleware)
+
+		// Health endpoint
+		if cfg.Database != nil {
+			r.Get("/api/health", NewHealthHandler(cfg.Database))
+		} else {
+			r.Get("/api/health", HealthHandler)
+		}
 
-	// Feed API
-	feedHandler := NewFeedHandler(cfg.FeedStore, cfg.Ingester)
-	r.Route("/api/feed", func(r chi.Router) {
-		r.Get("/health", feedHandler.Health)
-		r.Get("/", feedHandler.List)
-		r.Get("/stats", feedHandler.Stats)
-		r.Get("/event/{id}", feedHandler.GetEvent)
-		r.Get("/pr/{number}", feedHandler.GetByPR)
-		r.Get("/issue/{number}", feedHandler.GetByIssue)
-		r.Get("/user/{username}", feedHandler.GetByUser)
-		r.Get("/voters", feedHandler.GetVoters)
-		r.Get("/voters/{username}", feedHandler.GetVoter)
-		r.Get("/votes/pr/{number}", feedHandler.GetPRVotes)
+		// Feed API
+		feedHandler := NewFeedHandler(cfg.FeedStore, cfg.Ingester, cfg.Broker)
+		r.Route("/api/feed", func(r chi.Router) {
+			r.Get("/health", feedHandler.Health)
+			r.Get("/", feedHandler.List)
+			r.Get("/stream", feedHandler.Stream)
+			r.Get("/stats", feedHandler.Stats)
+			r.Get("/event/{id}", feedHandler.GetEvent)
+			r.Get("/pr/{number}", feedHandler.GetByPR)
+			r.Get("/issue/{number}", feedHandler.GetByIssue)
+			r.Get("/user/{username}", feedHandler.GetByUser)
+			r.Get("/voters", feedHandler.GetVoters)
+			r.Get("/voters/{username}", feedHandler.GetVoter)
+			r.Get("/voters/{username}/similar", feedHandler.GetSimilarVoters)
+			r.Get("/votes/pr/{number}", feedHandler.GetPRVotes)
+			r.Get("/votes/pr/{number}/timeline", feedHandler.GetPRVoteTimeline)
+			r.Get("/votes/pr/{number}/flags", feedHandler.GetPRVoteFlags)
+			r.Get("/votes/pr/{number}/changes", feedHandler.GetPRVoteChanges)
+			r.Get("/votes/prs", feedHandler.GetPRLeaderboard)
+			r.Get("/prs", feedHandler.ListPRs)
+			r.Get("/prs/{number}", feedHandler.GetPR)
+			r.Get("/analytics/blocs", feedHandler.GetVotingBlocs)
 
-		// Export: strict rate limit (2/min/IP) + concurrency cap (3 global) + 30s timeout
-		r.With(ExportGuardMiddleware(rateLimiters.Export)).
-			Get("/export", feedHandler.Export)
+			// Export: strict rate limit (2/min/IP) + concurrency cap (3 global) + 30s timeout
+			r.With(ExportGuardMiddleware(rateLimiters.Export)).
+				Get("/export", feedHandler.Export)
+			r.With(ExportGuardMiddleware(rateLimiters.Export)).
+				Get("/export/graph", feedHandler.ExportGraph)
+		})
 	})
 
+	// GitHub webhooks: push ingestion alongside polling (signature-verified).
+	// Outside the per-IP limiter: GitHub delivers from a small pool of IPs.
+	if cfg.WebhookSecret != "" && cfg.Ingester != nil {
+		webhookHandler := NewWebhookHandler(cfg.WebhookSecret, cfg.FeedStore, cfg.Ingester)
+		r.Post("/api/webhooks/github", webhookHandler.Receive)
+	}
+
 	return &RouterResult{
 		Router:       r,
 		RateLimiters: rateLimiters,
@@ -0,0 +1,113 @@
+package api
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/skridlevsky/openchaos-feed/internal/feed"
+)
+
+// maxWebhookBody matches GitHub's 25 MB cap on webhook payloads
+const maxWebhookBody = 25 << 20
+
+// WebhookHandler receives GitHub webhook deliveries as a push alternative to polling
+type WebhookHandler struct {
+	secret   []byte
+	store    *feed.Store
+	ingester *feed.Ingester
+}
+
+// NewWebhookHandler creates a new webhook handler
+func NewWebhookHandler(secret string, store *feed.Store, ingester *feed.Ingester) *WebhookHandler {
+	return &WebhookHandler{
+		secret:   []byte(secret),
+		store:    store,
+		ingester: ingester,
+	}
+}
+
+// WebhookResponse represents the webhook delivery acknowledgement
+type WebhookResponse struct {
+	Status   string `json:"status"`
+	Inserted int    `json:"inserted"`
+}
+
+// Receive handles POST /api/webhooks/github
+// Verifies X-Hub-Signature-256, dedupes by X-GitHub-Delivery, then ingests
+// through the same parser as the Events API poller.
+func (h *WebhookHandler) Receive(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBody))
+	if err != nil {
+		http.Error(w, "Payload too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	if !h.validSignature(r.Header.Get("X-Hub-Signature-256"), body) {
+		http.Error(w, "Invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	eventName := r.Header.Get("X-GitHub-Event")
+	deliveryID := r.Header.Get("X-GitHub-Delivery")
+	if eventName == "" || deliveryID == "" || len(deliveryID) > 64 {
+		http.Error(w, "Missing GitHub event headers", http.StatusBadRequest)
+		return
+	}
+
+	if eventName == "ping" {
+		respondJSON(w, http.StatusOK, WebhookResponse{Status: "pong"})
+		return
+	}
+
+	if !feed.SupportsWebhookEvent(eventName) {
+		respondJSON(w, http.StatusAccepted, WebhookResponse{Status: "ignored"})
+		return
+	}
+
+	isNew, err := h.store.RecordWebhookDelivery(ctx, deliveryID, eventName)
+	if err != nil {
+		slog.Error("Failed to record webhook delivery", "delivery", deliveryID, "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	if !isNew {
+		respondJSON(w, http.StatusOK, WebhookResponse{Status: "duplicate"})
+		return
+	}
+
+	inserted, err := h.ingester.IngestWebhook(ctx, eventName, body)
+	if err != nil {
+		slog.Error("Failed to ingest webhook", "event", eventName, "delivery", deliveryID, "error", err)
+		// Allow GitHub redelivery to retry; polling remains the fallback
+		if err := h.store.ForgetWebhookDelivery(ctx, deliveryID); err != nil {
+			slog.Warn("Failed to forget webhook delivery", "delivery", deliveryID, "error", err)
+		}
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, WebhookResponse{Status: "ok", Inserted: inserted})
+}
+
+// validSignature checks the "sha256=<hex>" HMAC of the raw body in constant time
+func (h *WebhookHandler) validSignature(header string, body []byte) bool {
+	sigHex, ok := strings.CutPrefix(header, "sha256=")
+	if !ok {
+		return false
+	}
+	sig, err := hex.DecodeString(sigHex)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, h.secret)
+	mac.Write(body)
+	return hmac.Equal(sig, mac.Sum(nil))
+}
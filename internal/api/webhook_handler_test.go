@@ -0,0 +1,41 @@
+package api
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+)
+
+func TestValidSignature(t *testing.T) {
+	h := NewWebhookHandler("s3cret", nil, nil)
+	body := []byte(`{"action":"opened","number":1}`)
+
+	sign := func(secret string, body []byte) string {
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write(body)
+		return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	}
+
+	tests := []struct {
+		name   string
+		header string
+		body   []byte
+		want   bool
+	}{
+		{"valid", sign("s3cret", body), body, true},
+		{"tampered body", sign("s3cret", body), []byte(`{"action":"opened","number":2}`), false},
+		{"wrong secret", sign("other", body), body, false},
+		{"missing header", "", body, false},
+		{"missing prefix", sign("s3cret", body)[len("sha256="):], body, false},
+		{"not hex", "sha256=zz", body, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := h.validSignature(tt.header, tt.body); got != tt.want {
+				t.Errorf("validSignature() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
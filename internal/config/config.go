@@ -14,10 +14,27 @@ type Config struct {
 	GitHubToken string
 	GitHubRepo  string
 
+	// Webhook receiver (disabled when empty)
+	GitHubWebhookSecret string
+
 	// Feed ingestion intervals
 	GitHubPollInterval        time.Duration
 	GitHubReactionsInterval   time.Duration
 	GitHubDiscussionsInterval time.Duration
+
+	// How often the feed_relevance view (sort=relevance) is recomputed
+	RelevanceRefreshInterval time.Duration
+
+	// What to keep of comments deleted on GitHub: "retain" or "redact"
+	DeletedCommentPolicy string
+
+	// Voter profile enrichment: how often it runs and how long a fetched
+	// profile is considered fresh
+	UserEnrichInterval time.Duration
+	UserProfileTTL     time.Duration
+
+	// How often voting blocs (/analytics/blocs) are recomputed
+	BlocAnalysisInterval time.Duration
 }
 
 // Load reads configuration from environment variables.
@@ -33,6 +50,11 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("GITHUB_TOKEN is required")
 	}
 
+	deletedCommentPolicy := getEnv("DELETED_COMMENT_POLICY", "retain")
+	if deletedCommentPolicy != "retain" && deletedCommentPolicy != "redact" {
+		return nil, fmt.Errorf("DELETED_COMMENT_POLICY must be retain or redact, got %q", deletedCommentPolicy)
+	}
+
 	return &Config{
 		Port:        getEnv("PORT", "8080"),
 		Env:         getEnv("ENV", "development"),
@@ -40,9 +62,19 @@ func Load() (*Config, error) {
 		GitHubToken: ghToken,
 		GitHubRepo:  getEnv("GITHUB_REPO", "skridlevsky/openchaos"),
 
+		GitHubWebhookSecret: os.Getenv("GITHUB_WEBHOOK_SECRET"),
+
 		GitHubPollInterval:        getDuration("GITHUB_POLL_INTERVAL", 60*time.Second),
 		GitHubReactionsInterval:   getDuration("GITHUB_REACTIONS_INTERVAL", 5*time.Minute),
 		GitHubDiscussionsInterval: getDuration("GITHUB_DISCUSSIONS_INTERVAL", 10*time.Minute),
+
+		RelevanceRefreshInterval: getDuration("RELEVANCE_REFRESH_INTERVAL", 5*time.Minute),
+		DeletedCommentPolicy:     deletedCommentPolicy,
+
+		UserEnrichInterval: getDuration("USER_ENRICH_INTERVAL", 15*time.Minute),
+		UserProfileTTL:     getDuration("USER_PROFILE_TTL", 7*24*time.Hour),
+
+		BlocAnalysisInterval: getDuration("BLOC_ANALYSIS_INTERVAL", time.Hour),
 	}, nil
 }
 
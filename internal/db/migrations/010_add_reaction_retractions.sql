@@ -0,0 +1,16 @@
+-- Record vote retractions instead of silently keeping stale reactions.
+-- When a reaction disappears from GitHub the original row is kept as public
+-- record and stamped with retracted_at; a reaction_removed event links back
+-- to it through related_event_id.
+ALTER TABLE events ADD COLUMN IF NOT EXISTS retracted_at TIMESTAMPTZ;
+ALTER TABLE events ADD COLUMN IF NOT EXISTS related_event_id UUID
+    REFERENCES events(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_events_related_event_id
+    ON events(related_event_id)
+    WHERE related_event_id IS NOT NULL;
+
+-- Active (non-retracted) votes are what every tally query reads
+CREATE INDEX IF NOT EXISTS idx_events_active_votes
+    ON events(pr_number, github_user, occurred_at DESC)
+    WHERE type = 'reaction' AND choice IS NOT NULL AND comment_id IS NULL AND retracted_at IS NULL;
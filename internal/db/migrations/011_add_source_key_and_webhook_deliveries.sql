@@ -0,0 +1,15 @@
+-- Namespaced natural key for events whose identity isn't a REST github_id
+-- (e.g. "push:<ref>:<head>"). Lets webhook deliveries and the Events API
+-- poller dedupe against each other when their payload shapes differ.
+ALTER TABLE events ADD COLUMN IF NOT EXISTS source_key VARCHAR(255);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_events_source_key
+    ON events(source_key);
+
+-- Processed webhook deliveries, keyed by X-GitHub-Delivery.
+-- GitHub retries and manual redeliveries reuse the same delivery GUID.
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    delivery_id VARCHAR(64) PRIMARY KEY,
+    event VARCHAR(50) NOT NULL,
+    received_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
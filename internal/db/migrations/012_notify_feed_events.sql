@@ -0,0 +1,18 @@
+-- Announce every new event on the feed_events channel (payload: event id).
+-- Each API replica LISTENs and pushes matching events to its SSE clients,
+-- so inserts from any process (ingester, webhooks, backfill) reach all of them.
+CREATE OR REPLACE FUNCTION notify_feed_event() RETURNS trigger AS $$
+BEGIN
+    PERFORM pg_notify('feed_events', NEW.id::text);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS events_notify_insert ON events;
+CREATE TRIGGER events_notify_insert
+    AFTER INSERT ON events
+    FOR EACH ROW EXECUTE FUNCTION notify_feed_event();
+
+-- Stream resumption (Last-Event-ID) walks events in insertion order
+CREATE INDEX IF NOT EXISTS idx_events_ingested_at_id
+    ON events(ingested_at, id);
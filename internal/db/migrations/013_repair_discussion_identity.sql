@@ -0,0 +1,34 @@
+-- GraphQL discussion rows used the discussion/comment/reaction *number* as
+-- github_id and 0 as github_user_id. Those small numbers collide with each
+-- other across discussions and with REST IDs in unique_github_id, and make
+-- discussion voters anonymous. New rows use source_key 'node:<node_id>'
+-- instead; the ingester claims the rows below by (type, discussion_number,
+-- github_user, occurred_at) and attaches their node key and real user ID on
+-- the next poll. User IDs aren't guessed from logins here: a login can since
+-- belong to another account, and a claim only fills an ID of 0.
+
+-- 1. Drop GraphQL discussion_created rows already covered by a REST
+--    DiscussionEvent row (real github_id and user ID) for the same discussion.
+DELETE FROM events legacy
+USING events rest
+WHERE legacy.type = 'discussion_created'
+  AND rest.type = 'discussion_created'
+  AND legacy.discussion_number = rest.discussion_number
+  AND legacy.github_user_id = 0
+  AND rest.github_user_id <> 0
+  AND legacy.source_key IS NULL;
+
+-- 2. Release the fake github_ids so they can't block real REST IDs.
+UPDATE events
+SET github_id = NULL
+WHERE discussion_number IS NOT NULL
+  AND github_user_id = 0
+  AND source_key IS NULL
+  AND type IN ('discussion_created', 'discussion_comment', 'reaction');
+
+-- 3. Discussion comment IDs were positional indexes, not GitHub IDs.
+UPDATE events
+SET comment_id = NULL
+WHERE type = 'discussion_comment'
+  AND github_user_id = 0
+  AND source_key IS NULL;
@@ -0,0 +1,7 @@
+-- Durable per-poller state (watermarks, cursors) so restarts resume where
+-- the previous process stopped instead of re-fetching from scratch.
+CREATE TABLE IF NOT EXISTS ingester_state (
+    key VARCHAR(100) PRIMARY KEY,
+    value JSONB NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
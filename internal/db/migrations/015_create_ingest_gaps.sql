@@ -0,0 +1,18 @@
+-- Windows the Events API poller could not see: the oldest event in a poll
+-- was newer than the last event seen by the previous poll, so everything in
+-- between fell out of GitHub's ~300-event window. Filled by a REST catch-up.
+CREATE TABLE IF NOT EXISTS ingest_gaps (
+    id BIGSERIAL PRIMARY KEY,
+    source VARCHAR(50) NOT NULL,
+    gap_start TIMESTAMPTZ NOT NULL,
+    gap_end TIMESTAMPTZ NOT NULL,
+    last_seen_event_id BIGINT,
+    first_event_id BIGINT,
+    detected_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    filled_at TIMESTAMPTZ,
+    events_recovered INTEGER NOT NULL DEFAULT 0,
+    fill_error TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_ingest_gaps_unfilled
+    ON ingest_gaps(detected_at) WHERE filled_at IS NULL;
@@ -0,0 +1,51 @@
+-- 016_rebuild_relevance_view.sql
+-- Rebuild feed_relevance with real reaction counts and a unique index so it
+-- can be refreshed CONCURRENTLY (see feed.RelevanceRefresher).
+--
+-- relevance_score = (1 + 3 * reactions + 2 * vote) / (hours_since + 2) ^ 1.5
+--   reactions: live reactions on the PR (PR lifecycle events) or on the comment
+--              (comment events), counted like GetPRReactionCounts and
+--              GetCommentReactionCounts
+--   vote:      1 for unretracted reactions carrying a choice
+-- Recency is computed at refresh time, so scores only change on refresh.
+
+DROP MATERIALIZED VIEW IF EXISTS feed_relevance;
+
+CREATE MATERIALIZED VIEW feed_relevance AS
+WITH pr_reactions AS (
+    SELECT pr_number, COUNT(*) AS cnt
+    FROM events
+    WHERE type = 'reaction' AND pr_number IS NOT NULL AND comment_id IS NULL
+      AND reaction_type IS NOT NULL AND retracted_at IS NULL
+    GROUP BY pr_number
+),
+comment_reactions AS (
+    SELECT comment_id, COUNT(*) AS cnt
+    FROM events
+    WHERE type = 'reaction' AND comment_id IS NOT NULL
+      AND reaction_type IS NOT NULL AND retracted_at IS NULL
+    GROUP BY comment_id
+)
+SELECT
+    e.id,
+    e.occurred_at,
+    (
+        (1.0
+         + 3.0 * COALESCE(pr.cnt, cr.cnt, 0)
+         + (CASE WHEN e.choice IS NOT NULL AND e.retracted_at IS NULL THEN 2.0 ELSE 0.0 END))
+        / POWER(GREATEST(EXTRACT(EPOCH FROM (NOW() - e.occurred_at)) / 3600.0, 0.0) + 2.0, 1.5)
+    )::double precision AS relevance_score
+FROM events e
+LEFT JOIN pr_reactions pr
+    ON e.type IN ('pr_opened', 'pr_closed', 'pr_merged', 'pr_reopened')
+   AND pr.pr_number = e.pr_number
+LEFT JOIN comment_reactions cr
+    ON e.type IN ('issue_comment', 'review_comment', 'commit_comment', 'discussion_comment')
+   AND cr.comment_id = e.comment_id
+WHERE e.occurred_at >= NOW() - INTERVAL '30 days'; -- Only recent events for performance
+
+-- Required by REFRESH MATERIALIZED VIEW CONCURRENTLY
+CREATE UNIQUE INDEX idx_feed_relevance_id ON feed_relevance(id);
+
+-- Keyset pagination on (score, occurred_at, id)
+CREATE INDEX idx_feed_relevance_score ON feed_relevance(relevance_score DESC, occurred_at DESC, id DESC);
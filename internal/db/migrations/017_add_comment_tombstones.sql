@@ -0,0 +1,7 @@
+-- 017_add_comment_tombstones.sql
+-- Comments deleted on GitHub are kept and marked instead of removed.
+-- A comment_deleted event (related_event_id → original row) records the deletion.
+
+ALTER TABLE events ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_events_deleted_at ON events(deleted_at) WHERE deleted_at IS NOT NULL;
@@ -0,0 +1,40 @@
+-- 018_create_prs_and_issues.sql
+-- Current state of each PR and issue, kept up to date by the ingester and
+-- sync engine from webhook/Events API payloads and REST listings.
+-- Rows only move forward: an upsert older than the stored updated_at is ignored.
+
+CREATE TABLE IF NOT EXISTS prs (
+    number INT PRIMARY KEY,
+    github_id BIGINT NOT NULL,
+    title TEXT NOT NULL,
+    author VARCHAR(255) NOT NULL,
+    author_id BIGINT NOT NULL,
+    state VARCHAR(10) NOT NULL, -- open, closed, merged
+    draft BOOLEAN NOT NULL DEFAULT FALSE,
+    labels TEXT[] NOT NULL DEFAULT '{}',
+    head_sha VARCHAR(40),
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    closed_at TIMESTAMPTZ,
+    merged_at TIMESTAMPTZ,
+    synced_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_prs_state ON prs(state, number DESC);
+CREATE INDEX IF NOT EXISTS idx_prs_author ON prs(author);
+
+CREATE TABLE IF NOT EXISTS issues (
+    number INT PRIMARY KEY,
+    github_id BIGINT NOT NULL,
+    title TEXT NOT NULL,
+    author VARCHAR(255) NOT NULL,
+    author_id BIGINT NOT NULL,
+    state VARCHAR(10) NOT NULL, -- open, closed
+    labels TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    closed_at TIMESTAMPTZ,
+    synced_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_issues_state ON issues(state, number DESC);
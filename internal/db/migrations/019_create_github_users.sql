@@ -0,0 +1,17 @@
+-- 019_create_github_users.sql
+-- GitHub account profiles of voters, fetched by the user enricher and
+-- refreshed once older than the configured TTL. Keyed by account ID, which
+-- survives renames.
+
+CREATE TABLE IF NOT EXISTS github_users (
+    id BIGINT PRIMARY KEY,
+    login VARCHAR(255) NOT NULL,
+    type VARCHAR(20), -- User, Bot, Organization; NULL when not_found
+    account_created_at TIMESTAMPTZ,
+    followers INT,
+    public_repos INT,
+    not_found BOOLEAN NOT NULL DEFAULT FALSE, -- Account deleted on GitHub
+    fetched_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_github_users_fetched ON github_users(fetched_at);
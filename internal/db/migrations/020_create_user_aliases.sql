@@ -0,0 +1,45 @@
+-- 020_create_user_aliases.sql
+-- Every login seen per GitHub account ID. Logins change on rename (and can be
+-- reclaimed by someone else), so people are identified by github_user_id and
+-- displayed under their latest login.
+
+CREATE TABLE IF NOT EXISTS user_aliases (
+    github_user_id BIGINT NOT NULL,
+    login VARCHAR(255) NOT NULL,
+    first_seen_at TIMESTAMPTZ NOT NULL,
+    last_seen_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (github_user_id, login)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_aliases_login ON user_aliases(LOWER(login), last_seen_at DESC);
+
+INSERT INTO user_aliases (github_user_id, login, first_seen_at, last_seen_at)
+SELECT github_user_id, github_user, MIN(occurred_at), MAX(occurred_at)
+FROM events
+WHERE github_user_id > 0
+GROUP BY github_user_id, github_user
+ON CONFLICT (github_user_id, login) DO NOTHING;
+
+-- Record the login of every new event, whichever process inserts it
+CREATE OR REPLACE FUNCTION record_user_alias() RETURNS trigger AS $$
+BEGIN
+    INSERT INTO user_aliases (github_user_id, login, first_seen_at, last_seen_at)
+    VALUES (NEW.github_user_id, NEW.github_user, NEW.occurred_at, NEW.occurred_at)
+    ON CONFLICT (github_user_id, login) DO UPDATE SET
+        first_seen_at = LEAST(user_aliases.first_seen_at, EXCLUDED.first_seen_at),
+        last_seen_at = GREATEST(user_aliases.last_seen_at, EXCLUDED.last_seen_at);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS events_record_user_alias ON events;
+CREATE TRIGGER events_record_user_alias
+    AFTER INSERT ON events
+    FOR EACH ROW WHEN (NEW.github_user_id > 0)
+    EXECUTE FUNCTION record_user_alias();
+
+-- Latest known login per account
+CREATE OR REPLACE VIEW user_current_login AS
+SELECT DISTINCT ON (github_user_id) github_user_id, login
+FROM user_aliases
+ORDER BY github_user_id, last_seen_at DESC;
@@ -0,0 +1,19 @@
+-- 021_create_voting_blocs.sql
+-- Voting blocs: clusters of voters whose last-vote-wins choices agree on
+-- most co-voted PRs. Recomputed wholesale by the bloc analyzer so
+-- /analytics/blocs is a plain read.
+
+CREATE TABLE IF NOT EXISTS voting_blocs (
+    id INT PRIMARY KEY,
+    size INT NOT NULL,
+    cohesion DOUBLE PRECISION NOT NULL, -- Mean pairwise agreement rate
+    computed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS voting_bloc_members (
+    bloc_id INT NOT NULL REFERENCES voting_blocs(id) ON DELETE CASCADE,
+    github_user VARCHAR(255) NOT NULL,
+    github_user_id BIGINT NOT NULL,
+    votes INT NOT NULL, -- PRs voted on when computed
+    PRIMARY KEY (bloc_id, github_user_id, github_user)
+);
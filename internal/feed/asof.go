@@ -0,0 +1,61 @@
+package feed
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// PointInTime restricts vote and stats aggregation to events that had
+// occurred by At, answering "what did the tally look like then?". A nil
+// *PointInTime means now.
+type PointInTime struct {
+	At time.Time
+
+	// Ingested additionally requires events to have been ingested by At,
+	// i.e. what this service itself could have reported at that moment
+	Ingested bool
+}
+
+// voteClause returns the condition selecting vote rows that counted at p.
+// A vote retracted after p still counted then.
+func (p *PointInTime) voteClause(argPos int) (string, []interface{}) {
+	if p == nil {
+		return " AND retracted_at IS NULL", nil
+	}
+	clause := fmt.Sprintf(" AND occurred_at <= $%d AND (retracted_at IS NULL OR retracted_at > $%d)", argPos, argPos)
+	if p.Ingested {
+		clause += fmt.Sprintf(" AND ingested_at <= $%d", argPos)
+	}
+	return clause, []interface{}{p.At}
+}
+
+// eventClause returns the condition selecting events that existed at p
+func (p *PointInTime) eventClause(argPos int) (string, []interface{}) {
+	if p == nil {
+		return "", nil
+	}
+	clause := fmt.Sprintf(" AND occurred_at <= $%d", argPos)
+	if p.Ingested {
+		clause += fmt.Sprintf(" AND ingested_at <= $%d", argPos)
+	}
+	return clause, []interface{}{p.At}
+}
+
+// GetPRMergedAt returns when a PR was merged, or nil if it hasn't been.
+// Prefers the prs table, falling back to the pr_merged event.
+func (s *Store) GetPRMergedAt(ctx context.Context, prNumber int) (*time.Time, error) {
+	var mergedAt *time.Time
+	err := s.pool.QueryRow(ctx, `
+		SELECT COALESCE(
+			(SELECT merged_at FROM prs WHERE number = $1),
+			(SELECT MIN(occurred_at) FROM events WHERE type = 'pr_merged' AND pr_number = $1)
+		)
+	`, prNumber).Scan(&mergedAt)
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, fmt.Errorf("failed to get PR merge time: %w", err)
+	}
+	return mergedAt, nil
+}
@@ -0,0 +1,469 @@
+package feed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Bloc thresholds: two voters are linked when they co-voted on at least
+// BlocMinOverlap PRs and made the same choice on at least BlocMinAgreement of
+// them. A bloc is a group in which every pair is linked.
+const (
+	BlocMinOverlap   = 5
+	BlocMinAgreement = 0.9
+)
+
+// VoterSimilarity is how closely another voter's votes match a given voter's
+// over the PRs both voted on (last vote wins, retracted votes excluded)
+type VoterSimilarity struct {
+	GitHubUser    string  `json:"githubUser"`
+	GitHubUserID  int64   `json:"githubUserId"`
+	Overlap       int     `json:"overlap"` // PRs both voted on
+	Agreements    int     `json:"agreements"`
+	Disagreements int     `json:"disagreements"`
+	Agreement     float64 `json:"agreement"` // Agreements / Overlap
+}
+
+// ErrVoterNotFound is returned by GetSimilarVoters for a user with no votes
+var ErrVoterNotFound = errors.New("voter not found")
+
+// GetSimilarVoters returns the voters who agree most often with githubUser
+// (any login the account has used), among those who co-voted on at least
+// minOverlap PRs. Highest agreement first, ties broken by larger overlap.
+func (s *Store) GetSimilarVoters(ctx context.Context, githubUser string, minOverlap, limit int) ([]*VoterSimilarity, error) {
+	userID, err := s.lookupUserID(ctx, githubUser)
+	if err != nil {
+		return nil, err
+	}
+	target, arg := userMatch(userID, githubUser, 1)
+
+	var exists bool
+	err = s.pool.QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM events
+			WHERE `+target+` AND type = 'reaction' AND choice IS NOT NULL AND comment_id IS NULL AND retracted_at IS NULL
+		)
+	`, arg).Scan(&exists)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check voter: %w", err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrVoterNotFound, githubUser)
+	}
+
+	query := `
+		WITH latest_votes AS (
+			SELECT DISTINCT ON (` + voterIdentity + `, pr_number)
+				github_user, github_user_id, choice, pr_number, occurred_at,
+				` + voterKey("") + ` as voter_key
+			FROM events
+			WHERE type = 'reaction' AND choice IS NOT NULL AND comment_id IS NULL AND retracted_at IS NULL
+			  AND pr_number IS NOT NULL
+			ORDER BY ` + voterIdentity + `, pr_number, occurred_at DESC
+		),
+		target AS (
+			SELECT voter_key, pr_number, choice FROM latest_votes WHERE ` + target + `
+		),
+		similar AS (
+			SELECT
+				(array_agg(o.github_user ORDER BY o.occurred_at DESC))[1] as github_user,
+				MAX(o.github_user_id) as github_user_id,
+				COUNT(*) as overlap,
+				COUNT(*) FILTER (WHERE o.choice = t.choice) as agreements
+			FROM target t
+			JOIN latest_votes o ON o.pr_number = t.pr_number AND o.voter_key <> t.voter_key
+			GROUP BY o.voter_key
+			HAVING COUNT(*) >= $2
+		)
+		SELECT COALESCE(l.login, s.github_user), s.github_user_id, s.overlap, s.agreements
+		FROM similar s
+		LEFT JOIN user_current_login l ON l.github_user_id = s.github_user_id
+		ORDER BY s.agreements::float / s.overlap DESC, s.overlap DESC, s.github_user_id, s.github_user
+		LIMIT $3
+	`
+
+	rows, err := s.pool.Query(ctx, query, arg, minOverlap, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get similar voters: %w", err)
+	}
+	defer rows.Close()
+
+	similar := []*VoterSimilarity{}
+	for rows.Next() {
+		v := &VoterSimilarity{}
+		if err := rows.Scan(&v.GitHubUser, &v.GitHubUserID, &v.Overlap, &v.Agreements); err != nil {
+			return nil, fmt.Errorf("failed to scan similar voter: %w", err)
+		}
+		v.Disagreements = v.Overlap - v.Agreements
+		v.Agreement = float64(v.Agreements) / float64(v.Overlap)
+		similar = append(similar, v)
+	}
+	return similar, nil
+}
+
+// VotingBloc is a group of voters who vote alike (see BlocMinAgreement)
+type VotingBloc struct {
+	ID         int           `json:"id"`
+	Size       int           `json:"size"`
+	Cohesion   float64       `json:"cohesion"` // Mean pairwise agreement rate
+	Members    []*BlocMember `json:"members"`
+	ComputedAt time.Time     `json:"computedAt"`
+}
+
+// BlocMember is a voter in a bloc
+type BlocMember struct {
+	GitHubUser   string `json:"githubUser"`
+	GitHubUserID int64  `json:"githubUserId"`
+	Votes        int    `json:"votes"` // PRs voted on when the bloc was computed
+}
+
+// blocVoter is one voter's vote vector: PR number → last choice
+type blocVoter struct {
+	Key    string // Identity key: account ID, or login for rows without one
+	Login  string
+	UserID int64
+	Votes  map[int]int8
+}
+
+// loadVoteVectors returns the current (last vote wins) votes of every voter
+func (s *Store) loadVoteVectors(ctx context.Context) ([]*blocVoter, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT
+			`+voterKey("v")+`,
+			COALESCE(l.login, v.github_user), v.github_user_id, v.pr_number, v.choice
+		FROM (
+			SELECT DISTINCT ON (`+voterIdentity+`, pr_number)
+				github_user, github_user_id, pr_number, choice
+			FROM events
+			WHERE type = 'reaction' AND choice IS NOT NULL AND comment_id IS NULL AND retracted_at IS NULL
+			  AND pr_number IS NOT NULL
+			ORDER BY `+voterIdentity+`, pr_number, occurred_at DESC
+		) v
+		LEFT JOIN user_current_login l ON l.github_user_id = v.github_user_id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load vote vectors: %w", err)
+	}
+	defer rows.Close()
+
+	byKey := make(map[string]*blocVoter)
+	voters := []*blocVoter{}
+	for rows.Next() {
+		var key, login string
+		var userID int64
+		var prNumber int
+		var choice int8
+		if err := rows.Scan(&key, &login, &userID, &prNumber, &choice); err != nil {
+			return nil, fmt.Errorf("failed to scan vote: %w", err)
+		}
+		voter, ok := byKey[key]
+		if !ok {
+			voter = &blocVoter{Key: key, Login: login, UserID: userID, Votes: make(map[int]int8)}
+			byKey[key] = voter
+			voters = append(voters, voter)
+		}
+		voter.Votes[prNumber] = choice
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read vote vectors: %w", err)
+	}
+	return voters, nil
+}
+
+// clusterBlocs groups voters into blocs by complete linkage: pairs are linked
+// when they co-voted on at least minOverlap PRs with at least minAgreement
+// agreement, and two groups merge (strongest links first) only if every
+// cross pair is linked. Returns blocs of two or more voters, largest first.
+func clusterBlocs(voters []*blocVoter, minOverlap int, minAgreement float64) []*VotingBloc {
+	type pair struct{ a, b int }
+	type link struct {
+		pair
+		overlap   int
+		agreement float64
+	}
+
+	// Count overlaps and agreements per pair via the voters of each PR
+	byPR := make(map[int][]int)
+	for i, v := range voters {
+		for pr := range v.Votes {
+			byPR[pr] = append(byPR[pr], i)
+		}
+	}
+	overlap := make(map[pair]int)
+	agree := make(map[pair]int)
+	for pr, idx := range byPR {
+		for x := 0; x < len(idx); x++ {
+			for y := x + 1; y < len(idx); y++ {
+				p := pair{idx[x], idx[y]}
+				if p.a > p.b {
+					p = pair{p.b, p.a}
+				}
+				overlap[p]++
+				if voters[p.a].Votes[pr] == voters[p.b].Votes[pr] {
+					agree[p]++
+				}
+			}
+		}
+	}
+
+	links := []link{}
+	rate := make(map[pair]float64)
+	for p, n := range overlap {
+		if n < minOverlap {
+			continue
+		}
+		r := float64(agree[p]) / float64(n)
+		if r < minAgreement {
+			continue
+		}
+		rate[p] = r
+		links = append(links, link{p, n, r})
+	}
+	sort.Slice(links, func(i, j int) bool {
+		if links[i].agreement != links[j].agreement {
+			return links[i].agreement > links[j].agreement
+		}
+		if links[i].overlap != links[j].overlap {
+			return links[i].overlap > links[j].overlap
+		}
+		if links[i].a != links[j].a {
+			return links[i].a < links[j].a
+		}
+		return links[i].b < links[j].b
+	})
+
+	linked := func(a, b int) (float64, bool) {
+		if a > b {
+			a, b = b, a
+		}
+		r, ok := rate[pair{a, b}]
+		return r, ok
+	}
+
+	// cluster[i] is the index of voter i's group in groups
+	cluster := make([]int, len(voters))
+	groups := make([][]int, len(voters))
+	for i := range voters {
+		cluster[i] = i
+		groups[i] = []int{i}
+	}
+	for _, l := range links {
+		ca, cb := cluster[l.a], cluster[l.b]
+		if ca == cb {
+			continue
+		}
+		complete := true
+		for _, x := range groups[ca] {
+			for _, y := range groups[cb] {
+				if _, ok := linked(x, y); !ok {
+					complete = false
+					break
+				}
+			}
+			if !complete {
+				break
+			}
+		}
+		if !complete {
+			continue
+		}
+		for _, y := range groups[cb] {
+			cluster[y] = ca
+		}
+		groups[ca] = append(groups[ca], groups[cb]...)
+		groups[cb] = nil
+	}
+
+	blocs := []*VotingBloc{}
+	for _, group := range groups {
+		if len(group) < 2 {
+			continue
+		}
+		sort.Ints(group)
+		bloc := &VotingBloc{Size: len(group)}
+		var total float64
+		pairs := 0
+		for x := 0; x < len(group); x++ {
+			v := voters[group[x]]
+			bloc.Members = append(bloc.Members, &BlocMember{GitHubUser: v.Login, GitHubUserID: v.UserID, Votes: len(v.Votes)})
+			for y := x + 1; y < len(group); y++ {
+				r, _ := linked(group[x], group[y])
+				total += r
+				pairs++
+			}
+		}
+		bloc.Cohesion = total / float64(pairs)
+		blocs = append(blocs, bloc)
+	}
+	sort.SliceStable(blocs, func(i, j int) bool {
+		if blocs[i].Size != blocs[j].Size {
+			return blocs[i].Size > blocs[j].Size
+		}
+		return blocs[i].Cohesion > blocs[j].Cohesion
+	})
+	for i, bloc := range blocs {
+		bloc.ID = i + 1
+	}
+	return blocs
+}
+
+// ReplaceVotingBlocs swaps the cached blocs for a fresh computation
+func (s *Store) ReplaceVotingBlocs(ctx context.Context, blocs []*VotingBloc) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin bloc update: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Members go with their bloc (ON DELETE CASCADE)
+	if _, err := tx.Exec(ctx, `DELETE FROM voting_blocs`); err != nil {
+		return fmt.Errorf("failed to clear voting blocs: %w", err)
+	}
+
+	for _, bloc := range blocs {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO voting_blocs (id, size, cohesion, computed_at)
+			VALUES ($1, $2, $3, NOW())
+		`, bloc.ID, bloc.Size, bloc.Cohesion)
+		if err != nil {
+			return fmt.Errorf("failed to insert voting bloc: %w", err)
+		}
+		for _, m := range bloc.Members {
+			_, err := tx.Exec(ctx, `
+				INSERT INTO voting_bloc_members (bloc_id, github_user, github_user_id, votes)
+				VALUES ($1, $2, $3, $4)
+			`, bloc.ID, m.GitHubUser, m.GitHubUserID, m.Votes)
+			if err != nil {
+				return fmt.Errorf("failed to insert voting bloc member: %w", err)
+			}
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit voting blocs: %w", err)
+	}
+	return nil
+}
+
+// ListVotingBlocs returns the cached blocs, largest first, with members
+// listed under their current login
+func (s *Store) ListVotingBlocs(ctx context.Context) ([]*VotingBloc, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT b.id, b.size, b.cohesion, b.computed_at,
+			COALESCE(l.login, m.github_user), m.github_user_id, m.votes
+		FROM voting_blocs b
+		JOIN voting_bloc_members m ON m.bloc_id = b.id
+		LEFT JOIN user_current_login l ON l.github_user_id = m.github_user_id
+		ORDER BY b.id, m.votes DESC, m.github_user_id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list voting blocs: %w", err)
+	}
+	defer rows.Close()
+
+	blocs := []*VotingBloc{}
+	var current *VotingBloc
+	for rows.Next() {
+		bloc := &VotingBloc{}
+		m := &BlocMember{}
+		if err := rows.Scan(&bloc.ID, &bloc.Size, &bloc.Cohesion, &bloc.ComputedAt, &m.GitHubUser, &m.GitHubUserID, &m.Votes); err != nil {
+			return nil, fmt.Errorf("failed to scan voting bloc: %w", err)
+		}
+		if current == nil || current.ID != bloc.ID {
+			current = bloc
+			blocs = append(blocs, current)
+		}
+		current.Members = append(current.Members, m)
+	}
+	return blocs, nil
+}
+
+// BlocAnalyzer periodically recomputes voting blocs into the voting_blocs
+// cache table
+type BlocAnalyzer struct {
+	store    *Store
+	interval time.Duration
+
+	// Lifecycle
+	stopCh   chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// NewBlocAnalyzer creates an analyzer that runs every interval
+func NewBlocAnalyzer(store *Store, interval time.Duration) *BlocAnalyzer {
+	return &BlocAnalyzer{
+		store:    store,
+		interval: interval,
+		stopCh:   make(chan struct{}),
+	}
+}
+
+// Run analyzes once immediately, then on every tick until stopped
+func (b *BlocAnalyzer) Run(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	// Cancel an in-flight run on Stop
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+		<-b.stopCh
+		cancel()
+	}()
+
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+
+		ticker := time.NewTicker(b.interval)
+		defer ticker.Stop()
+
+		b.analyze(ctx)
+		for {
+			select {
+			case <-ticker.C:
+				b.analyze(ctx)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// Stop halts the analyzer. Safe to call multiple times.
+func (b *BlocAnalyzer) Stop() {
+	b.stopOnce.Do(func() {
+		close(b.stopCh)
+		b.wg.Wait()
+	})
+}
+
+// analyze recomputes and stores the blocs, logging failures
+func (b *BlocAnalyzer) analyze(ctx context.Context) {
+	start := time.Now()
+	voters, err := b.store.loadVoteVectors(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			slog.Error("Voting bloc analysis failed", "error", err)
+		}
+		return
+	}
+
+	blocs := clusterBlocs(voters, BlocMinOverlap, BlocMinAgreement)
+	if err := b.store.ReplaceVotingBlocs(ctx, blocs); err != nil {
+		if ctx.Err() == nil {
+			slog.Error("Failed to store voting blocs", "error", err)
+		}
+		return
+	}
+	slog.Info("Voting blocs computed",
+		"voters", len(voters),
+		"blocs", len(blocs),
+		"duration", time.Since(start).Round(time.Millisecond),
+	)
+}
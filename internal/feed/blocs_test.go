@@ -0,0 +1,68 @@
+package feed
+
+import (
+	"testing"
+)
+
+func TestClusterBlocs(t *testing.T) {
+	votes := func(choices ...int8) map[int]int8 {
+		m := make(map[int]int8)
+		for i, c := range choices {
+			if c != 0 {
+				m[i+1] = c
+			}
+		}
+		return m
+	}
+
+	voters := []*blocVoter{
+		{Key: "1", Login: "alice", UserID: 1, Votes: votes(1, 1, -1, 1, 1, -1)},
+		{Key: "2", Login: "bob", UserID: 2, Votes: votes(1, 1, -1, 1, 1, -1)},
+		{Key: "3", Login: "carol", UserID: 3, Votes: votes(1, 1, -1, 1, 1, 1)},
+		// Agrees with carol everywhere, but only with alice and bob on 5 of 6
+		{Key: "4", Login: "dave", UserID: 4, Votes: votes(1, 1, -1, 1, 1, 1)},
+		// Opposes everyone
+		{Key: "5", Login: "erin", UserID: 5, Votes: votes(-1, -1, 1, -1, -1, 1)},
+		// Too few co-voted PRs to be linked
+		{Key: "login:frank", Login: "frank", Votes: votes(1, 1, -1, 0, 0, 0)},
+	}
+
+	tests := []struct {
+		name         string
+		minAgreement float64
+		want         [][]string
+	}{
+		{
+			name:         "strict",
+			minAgreement: 1,
+			want:         [][]string{{"alice", "bob"}, {"carol", "dave"}},
+		},
+		{
+			name:         "lenient",
+			minAgreement: 0.8,
+			want:         [][]string{{"alice", "bob", "carol", "dave"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blocs := clusterBlocs(voters, 5, tt.minAgreement)
+			if len(blocs) != len(tt.want) {
+				t.Fatalf("got %d blocs, want %d", len(blocs), len(tt.want))
+			}
+			for i, bloc := range blocs {
+				if bloc.ID != i+1 || bloc.Size != len(tt.want[i]) || len(bloc.Members) != bloc.Size {
+					t.Fatalf("bloc %d: id=%d size=%d members=%d", i, bloc.ID, bloc.Size, len(bloc.Members))
+				}
+				for j, m := range bloc.Members {
+					if m.GitHubUser != tt.want[i][j] {
+						t.Errorf("bloc %d member %d = %s, want %s", i, j, m.GitHubUser, tt.want[i][j])
+					}
+				}
+				if bloc.Cohesion < tt.minAgreement || bloc.Cohesion > 1 {
+					t.Errorf("bloc %d cohesion = %v", i, bloc.Cohesion)
+				}
+			}
+		})
+	}
+}
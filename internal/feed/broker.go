@@ -0,0 +1,190 @@
+package feed
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// NotifyChannel is the Postgres channel new event IDs are announced on (migration 012)
+const NotifyChannel = "feed_events"
+
+// Subscription buffer and cap. A subscriber that falls a full buffer behind is
+// dropped; its client reconnects with Last-Event-ID and replays from the store.
+const (
+	subscriptionBuffer = 64
+	maxSubscribers     = 500
+)
+
+// Subscription receives newly inserted events matching its filters.
+// Events is closed when the subscription is dropped or the broker stops.
+type Subscription struct {
+	Events  <-chan *Event
+	events  chan *Event
+	filters *ListFilters
+}
+
+// Broker fans out newly inserted events to live stream subscribers.
+// It LISTENs on a dedicated Postgres connection, so inserts made by any
+// replica or process reach subscribers on every replica.
+type Broker struct {
+	pool  *pgxpool.Pool
+	store *Store
+
+	mu   sync.Mutex
+	subs map[*Subscription]struct{}
+
+	// Lifecycle
+	stopCh   chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// NewBroker creates a new live event broker
+func NewBroker(pool *pgxpool.Pool, store *Store) *Broker {
+	return &Broker{
+		pool:   pool,
+		store:  store,
+		subs:   make(map[*Subscription]struct{}),
+		stopCh: make(chan struct{}),
+	}
+}
+
+// Run starts the LISTEN loop. Reconnects with a fixed backoff on failure.
+func (b *Broker) Run(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+		<-b.stopCh
+		cancel()
+	}()
+
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+		for {
+			err := b.listen(ctx)
+			if ctx.Err() != nil {
+				return
+			}
+			slog.Error("Feed broker lost LISTEN connection, retrying", "error", err)
+
+			select {
+			case <-time.After(5 * time.Second):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// Stop closes all subscriptions and shuts down the LISTEN loop. Safe to call multiple times.
+func (b *Broker) Stop() {
+	b.stopOnce.Do(func() {
+		close(b.stopCh)
+		b.wg.Wait()
+
+		b.mu.Lock()
+		for sub := range b.subs {
+			close(sub.events)
+			delete(b.subs, sub)
+		}
+		b.mu.Unlock()
+	})
+}
+
+// Subscribe registers a subscriber for events matching filters.
+// Returns an error if the broker is at capacity.
+func (b *Broker) Subscribe(filters *ListFilters) (*Subscription, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(b.subs) >= maxSubscribers {
+		return nil, fmt.Errorf("stream capacity full (%d subscribers)", maxSubscribers)
+	}
+
+	ch := make(chan *Event, subscriptionBuffer)
+	sub := &Subscription{Events: ch, events: ch, filters: filters}
+	b.subs[sub] = struct{}{}
+	return sub, nil
+}
+
+// Unsubscribe removes a subscriber. Safe to call after the broker dropped it.
+func (b *Broker) Unsubscribe(sub *Subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.subs[sub]; ok {
+		close(sub.events)
+		delete(b.subs, sub)
+	}
+}
+
+// listen holds one pooled connection in LISTEN mode and dispatches notifications
+func (b *Broker) listen(ctx context.Context) error {
+	conn, err := b.pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	defer func() {
+		// Don't hand a listening connection back to the pool
+		conn.Exec(context.Background(), "UNLISTEN *")
+		conn.Release()
+	}()
+
+	if _, err := conn.Exec(ctx, "LISTEN "+NotifyChannel); err != nil {
+		return fmt.Errorf("failed to listen: %w", err)
+	}
+	slog.Info("Feed broker listening", "channel", NotifyChannel)
+
+	for {
+		notification, err := conn.Conn().WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		if !b.hasSubscribers() {
+			continue // Skip the lookup when nobody is streaming (e.g. during backfill)
+		}
+
+		event, err := b.store.GetByID(ctx, notification.Payload)
+		if err != nil {
+			slog.Warn("Feed broker failed to load event", "id", notification.Payload, "error", err)
+			continue
+		}
+		b.publish(event)
+	}
+}
+
+// hasSubscribers reports whether any stream is connected
+func (b *Broker) hasSubscribers() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.subs) > 0
+}
+
+// publish delivers an event to every matching subscriber without blocking.
+// Subscribers whose buffer is full are dropped.
+func (b *Broker) publish(event *Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for sub := range b.subs {
+		if !sub.filters.Matches(event) {
+			continue
+		}
+		select {
+		case sub.events <- event:
+		default:
+			slog.Warn("Dropping slow stream subscriber")
+			close(sub.events)
+			delete(b.subs, sub)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package feed
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Vote change actions
+const (
+	VoteActionCast    = "cast"
+	VoteActionRetract = "retract" // Reaction found removed on GitHub (retracted_at)
+)
+
+// VoteHistory is one user's full sequence of vote reactions on a PR
+type VoteHistory struct {
+	GitHubUser   string        `json:"githubUser"`
+	GitHubUserID int64         `json:"githubUserId"`
+	Changes      []*VoteChange `json:"changes"`
+	Flips        int           `json:"flips"`    // Consecutive casts with different choices
+	HeldBoth     bool          `json:"heldBoth"` // Had 👍 and 👎 active at the same time
+	Current      int8          `json:"current"`  // Vote that counts now (last vote wins), 0 if none
+}
+
+// VoteChange is a vote reaction being cast or retracted
+type VoteChange struct {
+	At           time.Time `json:"at"`
+	Action       string    `json:"action"` // VoteActionCast or VoteActionRetract
+	Choice       int8      `json:"choice"`
+	ReactionType string    `json:"reactionType"`
+}
+
+// voteChangeRow is one vote reaction on a PR, including retracted ones
+type voteChangeRow struct {
+	User         string // Identity key: account ID, or login for rows without one
+	Login        string
+	UserID       int64
+	Choice       int8
+	ReactionType string
+	OccurredAt   time.Time
+	RetractedAt  *time.Time
+}
+
+// GetPRVoteChanges returns every voter's ordered vote history on a PR,
+// earliest voter first. Retraction times are when the removal was detected.
+func (s *Store) GetPRVoteChanges(ctx context.Context, prNumber int) ([]*VoteHistory, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT `+voterKey("e")+`,
+			COALESCE(l.login, e.github_user), e.github_user_id,
+			e.choice, COALESCE(e.reaction_type, ''), e.occurred_at, e.retracted_at
+		FROM events e
+		LEFT JOIN user_current_login l ON l.github_user_id = e.github_user_id
+		WHERE e.type = 'reaction' AND e.pr_number = $1 AND e.choice IS NOT NULL AND e.comment_id IS NULL
+		ORDER BY e.occurred_at ASC
+	`, prNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get PR vote changes: %w", err)
+	}
+	defer rows.Close()
+
+	var votes []voteChangeRow
+	for rows.Next() {
+		var v voteChangeRow
+		if err := rows.Scan(&v.User, &v.Login, &v.UserID, &v.Choice, &v.ReactionType, &v.OccurredAt, &v.RetractedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan vote change: %w", err)
+		}
+		votes = append(votes, v)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read PR vote changes: %w", err)
+	}
+
+	return buildVoteHistories(votes), nil
+}
+
+// buildVoteHistories groups vote reactions (ordered by occurred_at) by user
+// and replays each user's casts and retractions in time order
+func buildVoteHistories(votes []voteChangeRow) []*VoteHistory {
+	histories := []*VoteHistory{}
+	byUser := make(map[string]*VoteHistory)
+	var order []string
+	latest := make(map[string]time.Time) // Latest unretracted cast, for Current
+
+	for _, v := range votes {
+		h, ok := byUser[v.User]
+		if !ok {
+			h = &VoteHistory{GitHubUser: v.Login, GitHubUserID: v.UserID, Changes: []*VoteChange{}}
+			byUser[v.User] = h
+			order = append(order, v.User)
+		}
+		h.Changes = append(h.Changes, &VoteChange{At: v.OccurredAt, Action: VoteActionCast, Choice: v.Choice, ReactionType: v.ReactionType})
+		if v.RetractedAt != nil {
+			h.Changes = append(h.Changes, &VoteChange{At: *v.RetractedAt, Action: VoteActionRetract, Choice: v.Choice, ReactionType: v.ReactionType})
+		} else if t, ok := latest[v.User]; !ok || !v.OccurredAt.Before(t) {
+			latest[v.User] = v.OccurredAt
+			h.Current = v.Choice
+		}
+	}
+
+	for _, user := range order {
+		h := byUser[user]
+		sort.SliceStable(h.Changes, func(i, j int) bool { return h.Changes[i].At.Before(h.Changes[j].At) })
+
+		var lastCast int8
+		active := make(map[int8]int) // Choice → reactions currently present
+		for _, c := range h.Changes {
+			if c.Action == VoteActionRetract {
+				active[c.Choice]--
+				continue
+			}
+			if lastCast != 0 && c.Choice != lastCast {
+				h.Flips++
+			}
+			lastCast = c.Choice
+			active[c.Choice]++
+			if active[1] > 0 && active[-1] > 0 {
+				h.HeldBoth = true
+			}
+		}
+		histories = append(histories, h)
+	}
+	return histories
+}
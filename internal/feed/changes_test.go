@@ -0,0 +1,65 @@
+package feed
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBuildVoteHistories(t *testing.T) {
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	at := func(minutes int) time.Time { return base.Add(time.Duration(minutes) * time.Minute) }
+	ptr := func(t time.Time) *time.Time { return &t }
+
+	votes := []voteChangeRow{
+		// alice: 👍, adds 👎 while 👍 is still there, then removes the 👍
+		{User: "1", Login: "alice", UserID: 1, Choice: 1, ReactionType: "+1", OccurredAt: at(0), RetractedAt: ptr(at(30))},
+		{User: "2", Login: "bob", UserID: 2, Choice: -1, ReactionType: "-1", OccurredAt: at(5)},
+		{User: "1", Login: "alice", UserID: 1, Choice: -1, ReactionType: "-1", OccurredAt: at(10)},
+		// carol: 👍, removes it, 👎, removes it, 👍 again
+		{User: "login:carol", Login: "carol", Choice: 1, ReactionType: "+1", OccurredAt: at(20), RetractedAt: ptr(at(25))},
+		{User: "login:carol", Login: "carol", Choice: -1, ReactionType: "-1", OccurredAt: at(40), RetractedAt: ptr(at(45))},
+		{User: "login:carol", Login: "carol", Choice: 1, ReactionType: "+1", OccurredAt: at(50)},
+	}
+
+	histories := buildVoteHistories(votes)
+
+	want := []struct {
+		user     string
+		changes  []string // action:choice
+		flips    int
+		heldBoth bool
+		current  int8
+	}{
+		{"alice", []string{"cast:1", "cast:-1", "retract:1"}, 1, true, -1},
+		{"bob", []string{"cast:-1"}, 0, false, -1},
+		{"carol", []string{"cast:1", "retract:1", "cast:-1", "retract:-1", "cast:1"}, 2, false, 1},
+	}
+
+	if len(histories) != len(want) {
+		t.Fatalf("got %d histories, want %d", len(histories), len(want))
+	}
+	for i, w := range want {
+		h := histories[i]
+		if h.GitHubUser != w.user {
+			t.Fatalf("history %d is %s, want %s", i, h.GitHubUser, w.user)
+		}
+		var changes []string
+		for _, c := range h.Changes {
+			changes = append(changes, c.Action+":"+map[int8]string{1: "1", -1: "-1"}[c.Choice])
+		}
+		if len(changes) != len(w.changes) {
+			t.Errorf("%s: changes %v, want %v", w.user, changes, w.changes)
+		} else {
+			for j := range changes {
+				if changes[j] != w.changes[j] {
+					t.Errorf("%s: changes %v, want %v", w.user, changes, w.changes)
+					break
+				}
+			}
+		}
+		if h.Flips != w.flips || h.HeldBoth != w.heldBoth || h.Current != w.current {
+			t.Errorf("%s: flips=%d heldBoth=%v current=%d, want %d %v %d",
+				w.user, h.Flips, h.HeldBoth, h.Current, w.flips, w.heldBoth, w.current)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package feed
+
+import (
+	"encoding/json"
+
+	"github.com/skridlevsky/openchaos-feed/internal/github"
+)
+
+// DiscussionEvents converts a fully fetched GraphQL discussion into feed events:
+// the discussion itself, its comments and replies, and reactions on each.
+// Every event is keyed by its GraphQL node ID (see nodeSourceKey).
+func DiscussionEvents(discussion github.Discussion) []*Event {
+	discussionNumber := discussion.Number
+	discussionPayload, _ := json.Marshal(discussion)
+
+	events := []*Event{{
+		Type:             EventDiscussionCreated,
+		GitHubUser:       discussion.Author.Login,
+		GitHubUserID:     discussion.Author.DatabaseID,
+		DiscussionNumber: &discussionNumber,
+		SourceKey:        nodeSourceKey(discussion.ID),
+		Payload:          discussionPayload,
+		ContentHash:      computeContentHash(discussionPayload),
+		OccurredAt:       discussion.CreatedAt,
+	}}
+
+	for _, reaction := range discussion.Reactions {
+		events = append(events, discussionReactionEvent(reaction, discussionNumber, nil))
+	}
+
+	for _, comment := range discussion.Comments {
+		events = append(events, discussionCommentEvents(comment, discussionNumber)...)
+		for _, reply := range comment.Replies {
+			events = append(events, discussionCommentEvents(reply, discussionNumber)...)
+		}
+	}
+
+	return events
+}
+
+// discussionCommentEvents builds a comment (or reply) event and its reaction events
+func discussionCommentEvents(comment github.DiscussionComment, discussionNumber int) []*Event {
+	commentPayload, _ := json.Marshal(comment)
+
+	commentID := comment.DatabaseID
+	events := []*Event{{
+		Type:             EventDiscussionComment,
+		GitHubUser:       comment.Author.Login,
+		GitHubUserID:     comment.Author.DatabaseID,
+		DiscussionNumber: &discussionNumber,
+		CommentID:        &commentID,
+		SourceKey:        nodeSourceKey(comment.ID),
+		Payload:          commentPayload,
+		ContentHash:      computeContentHash(commentPayload),
+		OccurredAt:       comment.CreatedAt,
+	}}
+
+	for _, reaction := range comment.Reactions {
+		events = append(events, discussionReactionEvent(reaction, discussionNumber, &commentID))
+	}
+	return events
+}
+
+// discussionReactionEvent builds a reaction event on a discussion, or on one of
+// its comments when commentID is set
+func discussionReactionEvent(reaction github.DiscussionReaction, discussionNumber int, commentID *int64) *Event {
+	// Normalize GraphQL uppercase types (THUMBS_UP → +1) before storage
+	reaction.Content = NormalizeReactionContent(reaction.Content)
+	reactionPayload, _ := json.Marshal(reaction)
+
+	var choice *int8
+	if reaction.Content == "+1" {
+		c := int8(1)
+		choice = &c
+	} else if reaction.Content == "-1" {
+		c := int8(-1)
+		choice = &c
+	}
+
+	reactionType := reaction.Content
+	return &Event{
+		Type:             EventReaction,
+		GitHubUser:       reaction.User.Login,
+		GitHubUserID:     reaction.User.DatabaseID,
+		DiscussionNumber: &discussionNumber,
+		CommentID:        commentID,
+		Choice:           choice,
+		ReactionType:     &reactionType,
+		SourceKey:        nodeSourceKey(reaction.ID),
+		Payload:          reactionPayload,
+		ContentHash:      computeContentHash(reactionPayload),
+		OccurredAt:       reaction.CreatedAt,
+	}
+}
+
+// nodeSourceKey namespaces a GraphQL global node ID for the source_key column.
+// GraphQL database IDs are only unique per type, so they can't share github_id
+// with REST IDs; node IDs are globally unique. Returns nil for an empty ID.
+func nodeSourceKey(nodeID string) *string {
+	if nodeID == "" {
+		return nil
+	}
+	key := "node:" + nodeID
+	return &key
+}
@@ -0,0 +1,250 @@
+package feed
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/skridlevsky/openchaos-feed/internal/github"
+)
+
+// UserProfile is a voter's GitHub account profile (github_users table, migration 019)
+type UserProfile struct {
+	Type             string     `json:"type,omitempty"` // User, Bot or Organization
+	AccountCreatedAt *time.Time `json:"accountCreatedAt,omitempty"`
+	AccountAgeDays   *int       `json:"accountAgeDays,omitempty"` // As of now
+	Followers        int        `json:"followers"`
+	PublicRepos      int        `json:"publicRepos"`
+	NotFound         bool       `json:"notFound,omitempty"` // Account deleted on GitHub
+	FetchedAt        time.Time  `json:"fetchedAt"`
+}
+
+// profileColumns selects a joined github_users row (alias u) for profileRow
+const profileColumns = `u.id, u.type, u.account_created_at, u.followers, u.public_repos, u.not_found, u.fetched_at`
+
+// profileRow holds the nullable columns of a LEFT JOINed github_users row
+type profileRow struct {
+	id               *int64
+	userType         *string
+	accountCreatedAt *time.Time
+	followers        *int
+	publicRepos      *int
+	notFound         *bool
+	fetchedAt        *time.Time
+}
+
+// scanTargets returns pointers to the fields of profileColumns, in order
+func (r *profileRow) scanTargets() []interface{} {
+	return []interface{}{&r.id, &r.userType, &r.accountCreatedAt, &r.followers, &r.publicRepos, &r.notFound, &r.fetchedAt}
+}
+
+// profile returns the scanned profile, or nil if the user hasn't been enriched yet
+func (r *profileRow) profile() *UserProfile {
+	if r.id == nil {
+		return nil
+	}
+	p := &UserProfile{
+		AccountCreatedAt: r.accountCreatedAt,
+		NotFound:         *r.notFound,
+		FetchedAt:        *r.fetchedAt,
+	}
+	if r.userType != nil {
+		p.Type = *r.userType
+	}
+	if r.followers != nil {
+		p.Followers = *r.followers
+	}
+	if r.publicRepos != nil {
+		p.PublicRepos = *r.publicRepos
+	}
+	if r.accountCreatedAt != nil {
+		days := int(time.Since(*r.accountCreatedAt).Hours() / 24)
+		p.AccountAgeDays = &days
+	}
+	return p
+}
+
+// StaleUser identifies a voter whose profile is missing or older than the TTL
+type StaleUser struct {
+	ID    int64
+	Login string
+}
+
+// ListStaleUsers returns voters with no profile first, then those whose
+// profile was fetched before now-ttl, oldest first
+func (s *Store) ListStaleUsers(ctx context.Context, ttl time.Duration, limit int) ([]StaleUser, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT v.github_user_id, v.github_user
+		FROM (
+			SELECT DISTINCT ON (github_user_id) github_user_id, github_user
+			FROM events
+			WHERE type = 'reaction' AND choice IS NOT NULL AND comment_id IS NULL AND github_user_id > 0
+			ORDER BY github_user_id, occurred_at DESC
+		) v
+		LEFT JOIN github_users u ON u.id = v.github_user_id
+		WHERE u.id IS NULL OR u.fetched_at < $1
+		ORDER BY u.fetched_at ASC NULLS FIRST
+		LIMIT $2
+	`, time.Now().Add(-ttl), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stale users: %w", err)
+	}
+	defer rows.Close()
+
+	users := []StaleUser{}
+	for rows.Next() {
+		var u StaleUser
+		if err := rows.Scan(&u.ID, &u.Login); err != nil {
+			return nil, fmt.Errorf("failed to scan stale user: %w", err)
+		}
+		users = append(users, u)
+	}
+	return users, nil
+}
+
+// UpsertUserProfile stores a fetched profile. A nil user records that the
+// account no longer exists, so it isn't refetched until the TTL passes.
+func (s *Store) UpsertUserProfile(ctx context.Context, id int64, login string, user *github.User) error {
+	var err error
+	if user == nil {
+		_, err = s.pool.Exec(ctx, `
+			INSERT INTO github_users (id, login, not_found, fetched_at)
+			VALUES ($1, $2, TRUE, NOW())
+			ON CONFLICT (id) DO UPDATE SET not_found = TRUE, fetched_at = NOW()
+		`, id, login)
+	} else {
+		_, err = s.pool.Exec(ctx, `
+			INSERT INTO github_users (id, login, type, account_created_at, followers, public_repos, not_found, fetched_at)
+			VALUES ($1, $2, $3, $4, $5, $6, FALSE, NOW())
+			ON CONFLICT (id) DO UPDATE SET
+				login = EXCLUDED.login,
+				type = EXCLUDED.type,
+				account_created_at = EXCLUDED.account_created_at,
+				followers = EXCLUDED.followers,
+				public_repos = EXCLUDED.public_repos,
+				not_found = FALSE,
+				fetched_at = NOW()
+		`, id, user.Login, user.Type, user.CreatedAt, user.Followers, user.PublicRepos)
+		if err == nil {
+			// The users API has the current login, catching renames before the
+			// account's next event does
+			_, err = s.pool.Exec(ctx, `
+				INSERT INTO user_aliases (github_user_id, login, first_seen_at, last_seen_at)
+				VALUES ($1, $2, NOW(), NOW())
+				ON CONFLICT (github_user_id, login) DO UPDATE SET last_seen_at = NOW()
+			`, id, user.Login)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to upsert user profile %d: %w", id, err)
+	}
+	return nil
+}
+
+// enrichBatch is how many profiles the enricher fetches per run
+const enrichBatch = 100
+
+// UserEnricher periodically fetches GitHub profiles for voters that have
+// none or whose profile is older than the TTL
+type UserEnricher struct {
+	githubClient *github.Client
+	store        *Store
+	interval     time.Duration
+	ttl          time.Duration
+
+	// Lifecycle
+	stopCh   chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// NewUserEnricher creates an enricher that runs every interval and refetches
+// profiles older than ttl
+func NewUserEnricher(githubClient *github.Client, store *Store, interval, ttl time.Duration) *UserEnricher {
+	return &UserEnricher{
+		githubClient: githubClient,
+		store:        store,
+		interval:     interval,
+		ttl:          ttl,
+		stopCh:       make(chan struct{}),
+	}
+}
+
+// Run enriches once immediately, then on every tick until stopped
+func (e *UserEnricher) Run(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	// Cancel an in-flight run (or rate limit backoff) on Stop
+	e.wg.Add(1)
+	go func() {
+		defer e.wg.Done()
+		<-e.stopCh
+		cancel()
+	}()
+
+	e.wg.Add(1)
+	go func() {
+		defer e.wg.Done()
+
+		ticker := time.NewTicker(e.interval)
+		defer ticker.Stop()
+
+		e.enrich(ctx)
+		for {
+			select {
+			case <-ticker.C:
+				e.enrich(ctx)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// Stop halts the enricher. Safe to call multiple times.
+func (e *UserEnricher) Stop() {
+	e.stopOnce.Do(func() {
+		close(e.stopCh)
+		e.wg.Wait()
+	})
+}
+
+// enrich fetches one batch of stale profiles
+func (e *UserEnricher) enrich(ctx context.Context) {
+	users, err := e.store.ListStaleUsers(ctx, e.ttl, enrichBatch)
+	if err != nil {
+		if ctx.Err() == nil {
+			slog.Error("User enrichment failed", "error", err)
+		}
+		return
+	}
+
+	fetched := 0
+	for _, u := range users {
+		if ctx.Err() != nil {
+			return
+		}
+
+		user, headers, err := e.githubClient.GetUserByID(ctx, u.ID)
+		if headers != nil {
+			backoffOnRateLimit(ctx, github.GetRateLimitFromHeaders(headers))
+		}
+		if err != nil {
+			// Likely rate limited; the rest of the batch waits for the next run
+			slog.Warn("Failed to fetch user profile", "user", u.Login, "error", err)
+			break
+		}
+
+		if err := e.store.UpsertUserProfile(ctx, u.ID, u.Login, user); err != nil {
+			slog.Warn("Failed to store user profile", "user", u.Login, "error", err)
+			continue
+		}
+		fetched++
+	}
+
+	if fetched > 0 {
+		slog.Info("User profiles enriched", "count", fetched, "stale", len(users))
+	}
+}
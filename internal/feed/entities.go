@@ -0,0 +1,318 @@
+package feed
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/skridlevsky/openchaos-feed/internal/github"
+)
+
+// PullRequest is the current state of a PR (prs table, migration 018)
+type PullRequest struct {
+	Number    int        `json:"number"`
+	GitHubID  int64      `json:"githubId"`
+	Title     string     `json:"title"`
+	Author    string     `json:"author"`
+	AuthorID  int64      `json:"authorId"`
+	State     string     `json:"state"` // open, closed, merged
+	Draft     bool       `json:"draft"`
+	Labels    []string   `json:"labels"`
+	HeadSHA   *string    `json:"headSha,omitempty"`
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedAt time.Time  `json:"updatedAt"`
+	ClosedAt  *time.Time `json:"closedAt,omitempty"`
+	MergedAt  *time.Time `json:"mergedAt,omitempty"`
+	SyncedAt  time.Time  `json:"syncedAt"`
+
+	// Vote tallies (latest vote per user, as GetPRVotes). Not stored.
+	Upvotes   int `json:"upvotes"`
+	Downvotes int `json:"downvotes"`
+}
+
+// Issue is the current state of an issue (issues table, migration 018)
+type Issue struct {
+	Number    int        `json:"number"`
+	GitHubID  int64      `json:"githubId"`
+	Title     string     `json:"title"`
+	Author    string     `json:"author"`
+	AuthorID  int64      `json:"authorId"`
+	State     string     `json:"state"` // open, closed
+	Labels    []string   `json:"labels"`
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedAt time.Time  `json:"updatedAt"`
+	ClosedAt  *time.Time `json:"closedAt,omitempty"`
+	SyncedAt  time.Time  `json:"syncedAt"`
+}
+
+// PullRequestFromREST converts a REST API PR listing
+func PullRequestFromREST(pr *github.GitHubPR) *PullRequest {
+	createdAt, _ := time.Parse(time.RFC3339, pr.CreatedAt)
+	updatedAt, _ := time.Parse(time.RFC3339, pr.UpdatedAt)
+
+	state := pr.State
+	if pr.Merged || pr.MergedAt != nil {
+		state = "merged"
+	}
+
+	entity := &PullRequest{
+		Number:    pr.Number,
+		GitHubID:  pr.ID,
+		Title:     pr.Title,
+		Author:    pr.User.Login,
+		AuthorID:  pr.User.ID,
+		State:     state,
+		Draft:     pr.Draft,
+		Labels:    labelNames(pr.Labels),
+		CreatedAt: createdAt,
+		UpdatedAt: updatedAt,
+		ClosedAt:  pr.ClosedAt,
+		MergedAt:  pr.MergedAt,
+	}
+	if pr.Head.SHA != "" {
+		entity.HeadSHA = &pr.Head.SHA
+	}
+	return entity
+}
+
+// IssueFromREST converts a REST API issue listing
+func IssueFromREST(issue *github.GitHubIssue) *Issue {
+	return &Issue{
+		Number:    issue.Number,
+		GitHubID:  issue.ID,
+		Title:     issue.Title,
+		Author:    issue.User.Login,
+		AuthorID:  issue.User.ID,
+		State:     issue.State,
+		Labels:    labelNames(issue.Labels),
+		CreatedAt: issue.CreatedAt,
+		UpdatedAt: issue.UpdatedAt,
+		ClosedAt:  issue.ClosedAt,
+	}
+}
+
+// labelNames flattens labels to their names
+func labelNames(labels []github.Label) []string {
+	names := make([]string, 0, len(labels))
+	for _, label := range labels {
+		names = append(names, label.Name)
+	}
+	return names
+}
+
+// trackEntity upserts the PR or issue snapshot carried by a PullRequestEvent
+// or IssuesEvent, for every action including ones with no feed event (e.g.
+// edited, unlabeled). Payloads without a usable snapshot are ignored.
+func (ing *Ingester) trackEntity(ctx context.Context, raw *github.RawGitHubEvent) {
+	var err error
+	switch raw.Type {
+	case "PullRequestEvent":
+		var payload github.PullRequestEventPayload
+		if json.Unmarshal(raw.Payload, &payload) != nil || payload.PullRequest.ID == 0 || payload.PullRequest.UpdatedAt.IsZero() {
+			return
+		}
+		pr := payload.PullRequest
+		state := pr.State
+		if pr.Merged || pr.MergedAt != nil {
+			state = "merged"
+		}
+		entity := &PullRequest{
+			Number:    payload.Number,
+			GitHubID:  pr.ID,
+			Title:     pr.Title,
+			Author:    pr.User.Login,
+			AuthorID:  pr.User.ID,
+			State:     state,
+			Draft:     pr.Draft,
+			Labels:    labelNames(pr.Labels),
+			CreatedAt: pr.CreatedAt,
+			UpdatedAt: pr.UpdatedAt,
+			ClosedAt:  pr.ClosedAt,
+			MergedAt:  pr.MergedAt,
+		}
+		if pr.Head.SHA != "" {
+			entity.HeadSHA = &pr.Head.SHA
+		}
+		err = ing.store.UpsertPR(ctx, entity)
+
+	case "IssuesEvent":
+		var payload github.IssuesEventPayload
+		if json.Unmarshal(raw.Payload, &payload) != nil || payload.Issue.ID == 0 || payload.Issue.UpdatedAt.IsZero() {
+			return
+		}
+		if payload.Action == "transferred" {
+			return // Now lives in another repository
+		}
+		issue := payload.Issue
+		err = ing.store.UpsertIssue(ctx, &Issue{
+			Number:    issue.Number,
+			GitHubID:  issue.ID,
+			Title:     issue.Title,
+			Author:    issue.User.Login,
+			AuthorID:  issue.User.ID,
+			State:     issue.State,
+			Labels:    labelNames(issue.Labels),
+			CreatedAt: issue.CreatedAt,
+			UpdatedAt: issue.UpdatedAt,
+			ClosedAt:  issue.ClosedAt,
+		})
+
+	default:
+		return
+	}
+
+	if err != nil {
+		slog.Warn("Failed to update entity state", "event_type", raw.Type, "error", err)
+	}
+}
+
+// UpsertPR stores a PR's state unless a newer one (by updated_at) is stored
+func (s *Store) UpsertPR(ctx context.Context, pr *PullRequest) error {
+	if pr.Labels == nil {
+		pr.Labels = []string{}
+	}
+	_, err := s.pool.Exec(ctx, `
+		INSERT INTO prs (
+			number, github_id, title, author, author_id, state, draft, labels,
+			head_sha, created_at, updated_at, closed_at, merged_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		ON CONFLICT (number) DO UPDATE SET
+			github_id = EXCLUDED.github_id,
+			title = EXCLUDED.title,
+			author = EXCLUDED.author,
+			author_id = EXCLUDED.author_id,
+			state = EXCLUDED.state,
+			draft = EXCLUDED.draft,
+			labels = EXCLUDED.labels,
+			head_sha = COALESCE(EXCLUDED.head_sha, prs.head_sha),
+			created_at = EXCLUDED.created_at,
+			updated_at = EXCLUDED.updated_at,
+			closed_at = EXCLUDED.closed_at,
+			merged_at = EXCLUDED.merged_at,
+			synced_at = NOW()
+		WHERE prs.updated_at <= EXCLUDED.updated_at
+	`, pr.Number, pr.GitHubID, pr.Title, pr.Author, pr.AuthorID, pr.State, pr.Draft, pr.Labels,
+		pr.HeadSHA, pr.CreatedAt, pr.UpdatedAt, pr.ClosedAt, pr.MergedAt)
+	if err != nil {
+		return fmt.Errorf("failed to upsert PR %d: %w", pr.Number, err)
+	}
+	return nil
+}
+
+// UpsertIssue stores an issue's state unless a newer one (by updated_at) is stored
+func (s *Store) UpsertIssue(ctx context.Context, issue *Issue) error {
+	if issue.Labels == nil {
+		issue.Labels = []string{}
+	}
+	_, err := s.pool.Exec(ctx, `
+		INSERT INTO issues (
+			number, github_id, title, author, author_id, state, labels,
+			created_at, updated_at, closed_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (number) DO UPDATE SET
+			github_id = EXCLUDED.github_id,
+			title = EXCLUDED.title,
+			author = EXCLUDED.author,
+			author_id = EXCLUDED.author_id,
+			state = EXCLUDED.state,
+			labels = EXCLUDED.labels,
+			created_at = EXCLUDED.created_at,
+			updated_at = EXCLUDED.updated_at,
+			closed_at = EXCLUDED.closed_at,
+			synced_at = NOW()
+		WHERE issues.updated_at <= EXCLUDED.updated_at
+	`, issue.Number, issue.GitHubID, issue.Title, issue.Author, issue.AuthorID, issue.State, issue.Labels,
+		issue.CreatedAt, issue.UpdatedAt, issue.ClosedAt)
+	if err != nil {
+		return fmt.Errorf("failed to upsert issue %d: %w", issue.Number, err)
+	}
+	return nil
+}
+
+// prColumns is the column list for PR queries, qualified for joins
+const prColumns = `p.number, p.github_id, p.title, p.author, p.author_id, p.state, p.draft, p.labels,
+			p.head_sha, p.created_at, p.updated_at, p.closed_at, p.merged_at, p.synced_at`
+
+// scanTargets returns pointers to the fields of prColumns, in order
+func (pr *PullRequest) scanTargets() []interface{} {
+	return []interface{}{
+		&pr.Number, &pr.GitHubID, &pr.Title, &pr.Author, &pr.AuthorID, &pr.State, &pr.Draft, &pr.Labels,
+		&pr.HeadSHA, &pr.CreatedAt, &pr.UpdatedAt, &pr.ClosedAt, &pr.MergedAt, &pr.SyncedAt,
+	}
+}
+
+// ListPRs returns PRs newest first with their vote tallies. state filters by
+// open/closed/merged when non-empty. cursor is the last PR number of the
+// previous page (0 for the first page).
+func (s *Store) ListPRs(ctx context.Context, state string, limit int, cursor int) ([]*PullRequest, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+
+	query := `
+		WITH latest_votes AS (
+			SELECT DISTINCT ON (pr_number, ` + voterIdentity + `)
+				pr_number, choice
+			FROM events
+			WHERE type = 'reaction' AND choice IS NOT NULL AND comment_id IS NULL AND retracted_at IS NULL
+			  AND pr_number IS NOT NULL
+			ORDER BY pr_number, ` + voterIdentity + `, occurred_at DESC
+		),
+		tallies AS (
+			SELECT
+				pr_number,
+				COUNT(*) FILTER (WHERE choice = 1) as upvotes,
+				COUNT(*) FILTER (WHERE choice = -1) as downvotes
+			FROM latest_votes
+			GROUP BY pr_number
+		)
+		SELECT ` + prColumns + `, COALESCE(t.upvotes, 0), COALESCE(t.downvotes, 0)
+		FROM prs p
+		LEFT JOIN tallies t ON t.pr_number = p.number
+		WHERE ($1 = '' OR p.state = $1)
+		  AND ($2 = 0 OR p.number < $2)
+		ORDER BY p.number DESC
+		LIMIT $3
+	`
+
+	rows, err := s.pool.Query(ctx, query, state, cursor, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list PRs: %w", err)
+	}
+	defer rows.Close()
+
+	prs := []*PullRequest{}
+	for rows.Next() {
+		pr := &PullRequest{}
+		if err := rows.Scan(append(pr.scanTargets(), &pr.Upvotes, &pr.Downvotes)...); err != nil {
+			return nil, fmt.Errorf("failed to scan PR: %w", err)
+		}
+		prs = append(prs, pr)
+	}
+	return prs, nil
+}
+
+// ErrPRNotFound is returned by GetPR for a PR not in the prs table
+var ErrPRNotFound = errors.New("PR not found")
+
+// GetPR returns a PR's stored state with its vote tallies
+func (s *Store) GetPR(ctx context.Context, number int) (*PullRequest, error) {
+	pr := &PullRequest{}
+	err := s.pool.QueryRow(ctx, `SELECT `+prColumns+` FROM prs p WHERE p.number = $1`, number).Scan(pr.scanTargets()...)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("%w: %d", ErrPRNotFound, number)
+		}
+		return nil, fmt.Errorf("failed to get PR: %w", err)
+	}
+
+	pr.Upvotes, pr.Downvotes, err = s.GetPRVotes(ctx, number, nil)
+	if err != nil {
+		return nil, err
+	}
+	return pr, nil
+}
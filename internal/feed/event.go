@@ -18,10 +18,18 @@ const (
 	EventPREdited       EventType = "pr_edited"
 	EventPRSynchronized EventType = "pr_synchronized"
 
+	// PR Triage
+	EventPRLabeled          EventType = "pr_labeled"
+	EventPRAssigned         EventType = "pr_assigned"
+	EventPRReviewRequested  EventType = "pr_review_requested"
+	EventPRConvertedToDraft EventType = "pr_converted_to_draft"
+	EventPRReadyForReview   EventType = "pr_ready_for_review"
+
 	// PR Reviews
 	EventReviewSubmitted EventType = "review_submitted"
 	EventReviewComment   EventType = "review_comment"
 	EventReviewDismissed EventType = "review_dismissed"
+	EventReviewEdited    EventType = "review_edited"
 
 	// Issue Lifecycle
 	EventIssueOpened   EventType = "issue_opened"
@@ -29,13 +37,20 @@ const (
 	EventIssueReopened EventType = "issue_reopened"
 	EventIssueEdited   EventType = "issue_edited"
 
+	// Issue Triage
+	EventIssueLabeled     EventType = "issue_labeled"
+	EventIssueLocked      EventType = "issue_locked"
+	EventIssueTransferred EventType = "issue_transferred"
+
 	// Comments
 	EventIssueComment      EventType = "issue_comment"
 	EventCommitComment     EventType = "commit_comment"
 	EventDiscussionComment EventType = "discussion_comment"
+	EventCommentDeleted    EventType = "comment_deleted" // Tombstone for an issue/review comment deleted on GitHub
 
 	// Reactions (votes and engagement)
-	EventReaction EventType = "reaction"
+	EventReaction        EventType = "reaction"
+	EventReactionRemoved EventType = "reaction_removed" // Reaction no longer present on GitHub (vote retracted)
 
 	// Repository
 	EventStar    EventType = "star"
@@ -50,8 +65,12 @@ const (
 	EventTagDeleted    EventType = "tag_deleted"
 
 	// Discussions
-	EventDiscussionCreated  EventType = "discussion_created"
-	EventDiscussionAnswered EventType = "discussion_answered"
+	EventDiscussionCreated         EventType = "discussion_created"
+	EventDiscussionAnswered        EventType = "discussion_answered"
+	EventDiscussionUnanswered      EventType = "discussion_unanswered"
+	EventDiscussionClosed          EventType = "discussion_closed"
+	EventDiscussionLocked          EventType = "discussion_locked"
+	EventDiscussionCategoryChanged EventType = "discussion_category_changed"
 
 	// Wiki
 	EventWikiEdit EventType = "wiki_edit"
@@ -73,16 +92,22 @@ type Event struct {
 	Choice           *int8           `json:"choice,omitempty"` // +1 or -1 for votes
 	ReactionType     *string         `json:"reactionType,omitempty"`
 	GitHubID         *int64          `json:"githubId,omitempty"`
+	SourceKey        *string         `json:"sourceKey,omitempty"` // Namespaced natural key when github_id isn't enough (e.g. push:<ref>:<head>)
 	Payload          json.RawMessage `json:"payload"`
 	ContentHash      string          `json:"contentHash"`
 	EditHistory      json.RawMessage `json:"editHistory"`
+	RelatedEventID   *string         `json:"relatedEventId,omitempty"` // e.g. reaction_removed → original reaction row
+	RetractedAt      *time.Time      `json:"retractedAt,omitempty"`    // Set on reactions that were later removed
+	DeletedAt        *time.Time      `json:"deletedAt,omitempty"`      // Set on comments deleted on GitHub (see comment_deleted)
 	OccurredAt       time.Time       `json:"occurredAt"`
 	IngestedAt       time.Time       `json:"ingestedAt"`
 	ReactionSummary  map[string]int  `json:"reactionSummary,omitempty"` // Populated post-query for comment events
+	RelevanceScore   *float64        `json:"relevanceScore,omitempty"`  // Set when listed with sort=relevance
 }
 
-// EditHistoryEntry records a previous version of comment body before an edit
+// EditHistoryEntry records a previous version of a comment, PR or issue before an edit
 type EditHistoryEntry struct {
+	Title    string    `json:"title,omitempty"` // PRs and issues only
 	Body     string    `json:"body"`
 	EditedAt time.Time `json:"editedAt"`
 }
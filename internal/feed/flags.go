@@ -0,0 +1,205 @@
+package feed
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Vote flag reasons. Flags are advisory: they're computed on request and
+// never change stored events or tallies.
+const (
+	FlagBurst           = "burst"             // Part of a burst of votes on the PR
+	FlagFirstEvent      = "first_event"       // The voter's first event in the repo is this vote
+	FlagStarForkSession = "star_fork_session" // Starred/forked around the time of voting
+	FlagIdenticalVotes  = "identical_votes"   // Same votes on the same PRs as other accounts
+	FlagNewAccount      = "new_account"       // GitHub account created shortly before voting
+)
+
+// Flag thresholds
+const (
+	flagBurstWindow     = 10 * time.Minute    // Window in which flagBurstMinVotes votes make a burst
+	flagBurstMinVotes   = 5                   // Votes within flagBurstWindow that make a burst
+	flagSessionWindow   = time.Hour           // Star/fork this close to the vote counts as the same session
+	flagIdenticalMinPRs = 3                   // Vote histories shorter than this are too common to compare
+	flagNewAccountAge   = 30 * 24 * time.Hour // Accounts younger than this at vote time
+)
+
+// VoteFlag is one reason a vote looks suspicious
+type VoteFlag struct {
+	Reason string `json:"reason"`
+	Detail string `json:"detail"`
+}
+
+// FlaggedVote is a current vote on a PR with any flags raised against it
+type FlaggedVote struct {
+	GitHubUser   string     `json:"githubUser"`
+	GitHubUserID int64      `json:"githubUserId"`
+	Choice       int8       `json:"choice"`
+	VotedAt      time.Time  `json:"votedAt"`
+	Flags        []VoteFlag `json:"flags"`
+}
+
+// voteFacts is what the heuristics know about a vote's author
+type voteFacts struct {
+	vote             *FlaggedVote
+	firstVoteAt      time.Time  // Earliest reaction vote on this PR, including retracted ones
+	firstSeenAt      time.Time  // Earliest event of any type
+	starForkAt       *time.Time // Star or fork closest to the vote
+	accountCreatedAt *time.Time // From the user enricher, if fetched
+	signature        string     // Current votes on every PR, "pr:choice,..."
+	signaturePRs     int
+}
+
+// GetPRVoteFlags returns the current votes on a PR (last vote wins), oldest
+// first, each annotated with the heuristics it trips
+func (s *Store) GetPRVoteFlags(ctx context.Context, prNumber int) ([]*FlaggedVote, error) {
+	rows, err := s.pool.Query(ctx, `
+		WITH votes AS (
+			SELECT DISTINCT ON (`+voterIdentity+`)
+				github_user, github_user_id, choice, occurred_at
+			FROM events
+			WHERE type = 'reaction' AND pr_number = $1 AND choice IS NOT NULL AND comment_id IS NULL AND retracted_at IS NULL
+			ORDER BY `+voterIdentity+`, occurred_at DESC
+		)
+		SELECT
+			COALESCE(l.login, v.github_user), v.github_user_id, v.choice, v.occurred_at,
+			fv.first_vote, fs.first_seen, sf.occurred_at, u.account_created_at,
+			COALESCE(sig.signature, ''), sig.prs
+		FROM votes v
+		CROSS JOIN LATERAL (
+			SELECT MIN(e.occurred_at) as first_vote FROM events e
+			WHERE e.type = 'reaction' AND e.pr_number = $1 AND e.choice IS NOT NULL AND e.comment_id IS NULL AND `+sameVoter+`
+		) fv
+		CROSS JOIN LATERAL (
+			SELECT MIN(e.occurred_at) as first_seen FROM events e WHERE `+sameVoter+`
+		) fs
+		LEFT JOIN LATERAL (
+			SELECT e.occurred_at FROM events e
+			WHERE e.type IN ('star', 'fork') AND `+sameVoter+`
+			ORDER BY ABS(EXTRACT(EPOCH FROM e.occurred_at - v.occurred_at))
+			LIMIT 1
+		) sf ON TRUE
+		CROSS JOIN LATERAL (
+			SELECT string_agg(x.pr_number || ':' || x.choice, ',' ORDER BY x.pr_number) as signature, COUNT(*) as prs
+			FROM (
+				SELECT DISTINCT ON (e.pr_number) e.pr_number, e.choice
+				FROM events e
+				WHERE e.type = 'reaction' AND e.choice IS NOT NULL AND e.comment_id IS NULL AND e.retracted_at IS NULL
+				  AND e.pr_number IS NOT NULL AND `+sameVoter+`
+				ORDER BY e.pr_number, e.occurred_at DESC
+			) x
+		) sig
+		LEFT JOIN github_users u ON u.id = v.github_user_id
+		LEFT JOIN user_current_login l ON l.github_user_id = v.github_user_id
+		ORDER BY v.occurred_at ASC
+	`, prNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get PR vote facts: %w", err)
+	}
+	defer rows.Close()
+
+	facts := []*voteFacts{}
+	votes := []*FlaggedVote{}
+	for rows.Next() {
+		vote := &FlaggedVote{Flags: []VoteFlag{}}
+		f := &voteFacts{vote: vote}
+		err := rows.Scan(
+			&vote.GitHubUser, &vote.GitHubUserID, &vote.Choice, &vote.VotedAt,
+			&f.firstVoteAt, &f.firstSeenAt, &f.starForkAt, &f.accountCreatedAt,
+			&f.signature, &f.signaturePRs,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan PR vote facts: %w", err)
+		}
+		facts = append(facts, f)
+		votes = append(votes, vote)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read PR vote facts: %w", err)
+	}
+
+	flagVotes(facts)
+	return votes, nil
+}
+
+// flagVotes runs every heuristic over a PR's votes, appending to their Flags
+func flagVotes(facts []*voteFacts) {
+	// Bursts: every vote inside a window holding flagBurstMinVotes or more
+	sorted := make([]*voteFacts, len(facts))
+	copy(sorted, facts)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].vote.VotedAt.Before(sorted[j].vote.VotedAt) })
+	inBurst := make([]int, len(sorted)) // Largest burst each vote belongs to
+	for i, j := 0, 0; i < len(sorted); i++ {
+		if j < i {
+			j = i
+		}
+		for j+1 < len(sorted) && sorted[j+1].vote.VotedAt.Sub(sorted[i].vote.VotedAt) <= flagBurstWindow {
+			j++
+		}
+		if n := j - i + 1; n >= flagBurstMinVotes {
+			for k := i; k <= j; k++ {
+				if n > inBurst[k] {
+					inBurst[k] = n
+				}
+			}
+		}
+	}
+	for i, f := range sorted {
+		if inBurst[i] > 0 {
+			f.flag(FlagBurst, fmt.Sprintf("one of %d votes within %d minutes", inBurst[i], int(flagBurstWindow.Minutes())))
+		}
+	}
+
+	// Identical vote histories across accounts
+	bySignature := make(map[string][]*voteFacts)
+	for _, f := range facts {
+		if f.signaturePRs >= flagIdenticalMinPRs {
+			bySignature[f.signature] = append(bySignature[f.signature], f)
+		}
+	}
+
+	for _, f := range facts {
+		if !f.firstSeenAt.Before(f.firstVoteAt) {
+			f.flag(FlagFirstEvent, "no earlier activity in the repo")
+		}
+
+		if f.starForkAt != nil {
+			gap := f.vote.VotedAt.Sub(*f.starForkAt)
+			if gap < 0 {
+				gap = -gap
+			}
+			if gap <= flagSessionWindow {
+				when := "after"
+				if f.starForkAt.Before(f.vote.VotedAt) {
+					when = "before"
+				}
+				f.flag(FlagStarForkSession, fmt.Sprintf("starred or forked %d minutes %s voting", int(gap.Minutes()), when))
+			}
+		}
+
+		if f.accountCreatedAt != nil {
+			age := f.vote.VotedAt.Sub(*f.accountCreatedAt)
+			if age < flagNewAccountAge {
+				f.flag(FlagNewAccount, fmt.Sprintf("account %d days old when voting", int(age.Hours()/24)))
+			}
+		}
+
+		if group := bySignature[f.signature]; len(group) > 1 {
+			var others []string
+			for _, other := range group {
+				if other != f {
+					others = append(others, other.vote.GitHubUser)
+				}
+			}
+			f.flag(FlagIdenticalVotes, fmt.Sprintf("same votes on %d PRs as %s", f.signaturePRs, strings.Join(others, ", ")))
+		}
+	}
+}
+
+// flag records a reason against the vote
+func (f *voteFacts) flag(reason, detail string) {
+	f.vote.Flags = append(f.vote.Flags, VoteFlag{Reason: reason, Detail: detail})
+}
@@ -0,0 +1,75 @@
+package feed
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFlagVotes(t *testing.T) {
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	ptr := func(t time.Time) *time.Time { return &t }
+	facts := func(user string, votedAt time.Time) *voteFacts {
+		return &voteFacts{
+			vote:        &FlaggedVote{GitHubUser: user, VotedAt: votedAt},
+			firstVoteAt: votedAt,
+			firstSeenAt: base.Add(-90 * 24 * time.Hour),
+			signature:   user, // Unique unless set
+		}
+	}
+
+	// A long-standing voter, days before the wave
+	veteran := facts("veteran", base.Add(-72*time.Hour))
+
+	// Five votes within ten minutes
+	wave := []*voteFacts{
+		facts("w1", base),
+		facts("w2", base.Add(2*time.Minute)),
+		facts("w3", base.Add(4*time.Minute)),
+		facts("w4", base.Add(6*time.Minute)),
+		facts("w5", base.Add(8*time.Minute)),
+	}
+	wave[0].firstSeenAt = wave[0].firstVoteAt
+	wave[1].starForkAt = ptr(base.Add(-20 * time.Minute))
+	wave[2].accountCreatedAt = ptr(base.Add(-3 * 24 * time.Hour))
+	wave[3].signature, wave[3].signaturePRs = "1:1,2:-1,7:1", 3
+	wave[4].signature, wave[4].signaturePRs = "1:1,2:-1,7:1", 3
+
+	// Starred well outside the session window, on an old account
+	late := facts("late", base.Add(48*time.Hour))
+	late.starForkAt = ptr(base)
+	late.accountCreatedAt = ptr(base.Add(-400 * 24 * time.Hour))
+
+	all := append([]*voteFacts{late, veteran}, wave...)
+	flagVotes(all)
+
+	want := map[string][]string{
+		"veteran": nil,
+		"late":    nil,
+		"w1":      {FlagBurst, FlagFirstEvent},
+		"w2":      {FlagBurst, FlagStarForkSession},
+		"w3":      {FlagBurst, FlagNewAccount},
+		"w4":      {FlagBurst, FlagIdenticalVotes},
+		"w5":      {FlagBurst, FlagIdenticalVotes},
+	}
+	for _, f := range all {
+		var got []string
+		for _, flag := range f.vote.Flags {
+			got = append(got, flag.Reason)
+		}
+		exp := want[f.vote.GitHubUser]
+		if len(got) != len(exp) {
+			t.Errorf("%s: flags %v, want %v", f.vote.GitHubUser, got, exp)
+			continue
+		}
+		for i := range exp {
+			if got[i] != exp[i] {
+				t.Errorf("%s: flags %v, want %v", f.vote.GitHubUser, got, exp)
+				break
+			}
+		}
+	}
+
+	if detail := wave[3].vote.Flags[1].Detail; detail != "same votes on 3 PRs as w5" {
+		t.Errorf("identical votes detail = %q", detail)
+	}
+}
@@ -0,0 +1,227 @@
+package feed
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/skridlevsky/openchaos-feed/internal/github"
+)
+
+// stateEventsLastSeen holds the newest Events API event processed (eventsCursor)
+const stateEventsLastSeen = "events.last_seen"
+
+// Gap is a time range the Events API poller missed
+type Gap struct {
+	ID              int64      `json:"id"`
+	Source          string     `json:"source"`
+	Start           time.Time  `json:"start"`
+	End             time.Time  `json:"end"`
+	LastSeenEventID int64      `json:"lastSeenEventId"`
+	FirstEventID    int64      `json:"firstEventId"`
+	DetectedAt      time.Time  `json:"detectedAt"`
+	FilledAt        *time.Time `json:"filledAt,omitempty"`
+	EventsRecovered int        `json:"eventsRecovered"`
+	FillError       *string    `json:"fillError,omitempty"`
+}
+
+// eventsCursor is the newest Events API event seen by a completed poll
+type eventsCursor struct {
+	ID        int64     `json:"id"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// RecordGap stores a detected gap and sets its ID and DetectedAt
+func (s *Store) RecordGap(ctx context.Context, gap *Gap) error {
+	err := s.pool.QueryRow(ctx, `
+		INSERT INTO ingest_gaps (source, gap_start, gap_end, last_seen_event_id, first_event_id)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, detected_at
+	`, gap.Source, gap.Start, gap.End, gap.LastSeenEventID, gap.FirstEventID).Scan(&gap.ID, &gap.DetectedAt)
+	if err != nil {
+		return fmt.Errorf("failed to record gap: %w", err)
+	}
+	return nil
+}
+
+// MarkGapFilled records a successful catch-up for a gap
+func (s *Store) MarkGapFilled(ctx context.Context, id int64, recovered int) error {
+	_, err := s.pool.Exec(ctx, `
+		UPDATE ingest_gaps
+		SET filled_at = NOW(), events_recovered = $2, fill_error = NULL
+		WHERE id = $1
+	`, id, recovered)
+	if err != nil {
+		return fmt.Errorf("failed to mark gap filled: %w", err)
+	}
+	return nil
+}
+
+// MarkGapFailed records a failed catch-up attempt; the gap stays unfilled
+func (s *Store) MarkGapFailed(ctx context.Context, id int64, recovered int, fillErr error) error {
+	_, err := s.pool.Exec(ctx, `
+		UPDATE ingest_gaps
+		SET events_recovered = events_recovered + $2, fill_error = $3
+		WHERE id = $1
+	`, id, recovered, fillErr.Error())
+	if err != nil {
+		return fmt.Errorf("failed to mark gap failed: %w", err)
+	}
+	return nil
+}
+
+// ListGaps returns gaps, newest first. If unfilledOnly, only gaps without a
+// successful catch-up are returned.
+func (s *Store) ListGaps(ctx context.Context, unfilledOnly bool, limit int) ([]*Gap, error) {
+	query := `
+		SELECT id, source, gap_start, gap_end, last_seen_event_id, first_event_id,
+			detected_at, filled_at, events_recovered, fill_error
+		FROM ingest_gaps
+	`
+	if unfilledOnly {
+		query += ` WHERE filled_at IS NULL`
+	}
+	query += ` ORDER BY detected_at DESC LIMIT $1`
+
+	rows, err := s.pool.Query(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list gaps: %w", err)
+	}
+	defer rows.Close()
+
+	var gaps []*Gap
+	for rows.Next() {
+		gap := &Gap{}
+		if err := rows.Scan(
+			&gap.ID, &gap.Source, &gap.Start, &gap.End, &gap.LastSeenEventID, &gap.FirstEventID,
+			&gap.DetectedAt, &gap.FilledAt, &gap.EventsRecovered, &gap.FillError,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan gap: %w", err)
+		}
+		gaps = append(gaps, gap)
+	}
+	return gaps, rows.Err()
+}
+
+// detectGap compares a poll's oldest event with the last event seen before it.
+// If the poll doesn't reach back to that event, events in between aged out of
+// GitHub's window: the gap is recorded and a REST catch-up is run.
+// The cursor only advances when advance is true (all events were stored).
+func (ing *Ingester) detectGap(ctx context.Context, events []github.RawGitHubEvent, advance bool) {
+	var newest, oldest eventsCursor
+	for _, raw := range events {
+		id, err := strconv.ParseInt(raw.ID, 10, 64)
+		if err != nil {
+			continue
+		}
+		if newest.ID == 0 || id > newest.ID {
+			newest = eventsCursor{ID: id, CreatedAt: raw.CreatedAt}
+		}
+		if oldest.ID == 0 || id < oldest.ID {
+			oldest = eventsCursor{ID: id, CreatedAt: raw.CreatedAt}
+		}
+	}
+	if newest.ID == 0 {
+		return
+	}
+
+	ing.mu.RLock()
+	lastSeen := ing.lastSeenEvent
+	ing.mu.RUnlock()
+
+	gapFound := false
+	if lastSeen.ID > 0 && oldest.ID > lastSeen.ID {
+		gap := &Gap{
+			Source:          "events_api",
+			Start:           lastSeen.CreatedAt,
+			End:             oldest.CreatedAt,
+			LastSeenEventID: lastSeen.ID,
+			FirstEventID:    oldest.ID,
+		}
+		if err := ing.store.RecordGap(ctx, gap); err != nil {
+			slog.Error("Failed to record Events API gap", "error", err)
+		} else {
+			gapFound = true
+			slog.Warn("Events API gap detected",
+				"gap_start", gap.Start,
+				"gap_end", gap.End,
+				"last_seen_event_id", gap.LastSeenEventID,
+				"first_event_id", gap.FirstEventID,
+			)
+		}
+	}
+
+	if advance && newest.ID > lastSeen.ID {
+		ing.mu.Lock()
+		ing.lastSeenEvent = newest
+		ing.mu.Unlock()
+		ing.saveState(ctx, stateEventsLastSeen, newest)
+	}
+
+	if gapFound {
+		// Non-blocking: a pending wake-up already covers this gap
+		select {
+		case ing.gapFillCh <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// gapFillLoop fills gaps whenever the events poller records one, so a slow
+// REST catch-up never delays the next Events API poll
+func (ing *Ingester) gapFillLoop(ctx context.Context) {
+	defer ing.wg.Done()
+
+	for {
+		select {
+		case <-ing.gapFillCh:
+			ing.fillGaps(ctx)
+		case <-ing.stopCh:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// fillGaps runs a REST catch-up for every unfilled gap, including ones whose
+// earlier catch-up failed
+func (ing *Ingester) fillGaps(ctx context.Context) {
+	gaps, err := ing.store.ListGaps(ctx, true, 20)
+	if err != nil {
+		slog.Error("Failed to list unfilled gaps", "error", err)
+		return
+	}
+
+	for _, gap := range gaps {
+		select {
+		case <-ing.stopCh:
+			return
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		recovered, err := ing.syncer.CatchUp(ctx, gap.Start, gap.End)
+		if err != nil {
+			slog.Error("Gap catch-up failed", "gap_id", gap.ID, "recovered", recovered, "error", err)
+			if err := ing.store.MarkGapFailed(ctx, gap.ID, recovered, err); err != nil {
+				slog.Error("Failed to record gap catch-up failure", "gap_id", gap.ID, "error", err)
+			}
+			continue
+		}
+
+		if err := ing.store.MarkGapFilled(ctx, gap.ID, recovered); err != nil {
+			slog.Error("Failed to mark gap filled", "gap_id", gap.ID, "error", err)
+			continue
+		}
+		slog.Info("Gap filled from REST API",
+			"gap_id", gap.ID,
+			"gap_start", gap.Start,
+			"gap_end", gap.End,
+			"events_recovered", recovered,
+		)
+	}
+}
@@ -0,0 +1,216 @@
+package feed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Interaction graph edge kinds
+const (
+	EdgeVote    = "vote"    // Voter → PR author, weight = sum of choices (last vote wins per PR)
+	EdgeComment = "comment" // Commenter → PR/issue/discussion author
+	EdgeReview  = "review"  // Reviewer → PR author
+)
+
+// GraphEdgeKinds lists every edge kind, in export order
+var GraphEdgeKinds = []string{EdgeVote, EdgeComment, EdgeReview}
+
+// GraphEdge is a weighted, directed edge between two people aggregated over
+// all interactions of one kind
+type GraphEdge struct {
+	Kind        string
+	SourceID    int64
+	SourceLogin string
+	TargetID    int64
+	TargetLogin string
+	Weight      int // Signed for votes; interaction count otherwise
+	Count       int // Number of interactions (PRs voted on, comments, reviews)
+	FirstAt     time.Time
+	LastAt      time.Time
+}
+
+// GraphNodeID returns a stable node ID for a person: the account ID, or the
+// login for people recorded without one
+func GraphNodeID(id int64, login string) string {
+	if id > 0 {
+		return strconv.FormatInt(id, 10)
+	}
+	return "login:" + login
+}
+
+// ErrGraphTruncated is returned by StreamInteractionGraph when the graph has
+// more edges than the limit; every pass still saw the first limit edges
+var ErrGraphTruncated = errors.New("interaction graph truncated at limit")
+
+// GraphFilters narrows the interaction graph
+type GraphFilters struct {
+	Kinds []string   // Edge kinds to include; empty for all
+	Since *time.Time // Interactions at or after
+	Until *time.Time // Interactions at or before
+}
+
+// StreamInteractionGraph reads the weighted directed graph of who interacted
+// with whose work: votes on PRs, comments and reviews, each pointing at the
+// PR/issue/discussion author. Authors come from the prs and issues tables and
+// discussion_created events; interactions on unknown parents and self-edges
+// are skipped. People are keyed by account ID and labelled by latest login.
+//
+// Edges are passed to each pass function in turn as rows are read, at most
+// limit of them. Passes run in one read-only snapshot, so writers that need
+// every node before any edge (GEXF) see the same graph each time. A pass
+// function error stops the read and is returned.
+func (s *Store) StreamInteractionGraph(ctx context.Context, filters GraphFilters, limit int, passes ...func(*GraphEdge) error) error {
+	var window string
+	args := []interface{}{}
+	argPos := 1
+	if filters.Since != nil {
+		window += fmt.Sprintf(" AND e.occurred_at >= $%d", argPos)
+		args = append(args, *filters.Since)
+		argPos++
+	}
+	if filters.Until != nil {
+		window += fmt.Sprintf(" AND e.occurred_at <= $%d", argPos)
+		args = append(args, *filters.Until)
+		argPos++
+	}
+
+	kinds := filters.Kinds
+	if len(kinds) == 0 {
+		kinds = GraphEdgeKinds
+	}
+	args = append(args, kinds)
+	kindsArg := argPos
+	argPos++
+	args = append(args, limit+1) // One extra row detects truncation
+	limitArg := argPos
+
+	query := `
+		WITH votes AS (
+			SELECT DISTINCT ON (` + voterIdentity + `, pr_number)
+				github_user, github_user_id, pr_number, choice, occurred_at
+			FROM events e
+			WHERE type = 'reaction' AND choice IS NOT NULL AND comment_id IS NULL AND retracted_at IS NULL
+			  AND pr_number IS NOT NULL` + window + `
+			ORDER BY ` + voterIdentity + `, pr_number, occurred_at DESC
+		),
+		discussion_authors AS (
+			SELECT DISTINCT ON (discussion_number) discussion_number, github_user, github_user_id
+			FROM events
+			WHERE type = 'discussion_created'
+			ORDER BY discussion_number, occurred_at ASC
+		),
+		interactions AS (
+			SELECT 'vote' as kind, v.github_user as s_login, v.github_user_id as s_id,
+				p.author as t_login, p.author_id as t_id, v.choice::int as weight, v.occurred_at
+			FROM votes v
+			JOIN prs p ON p.number = v.pr_number
+
+			UNION ALL
+
+			SELECT 'comment', e.github_user, e.github_user_id,
+				COALESCE(p.author, i.author, d.github_user), COALESCE(p.author_id, i.author_id, d.github_user_id), 1, e.occurred_at
+			FROM events e
+			LEFT JOIN prs p ON p.number = e.pr_number
+			LEFT JOIN issues i ON i.number = e.issue_number
+			LEFT JOIN discussion_authors d ON d.discussion_number = e.discussion_number
+			WHERE e.type IN ('issue_comment', 'review_comment', 'discussion_comment')
+			  AND e.deleted_at IS NULL` + window + `
+
+			UNION ALL
+
+			SELECT 'review', e.github_user, e.github_user_id, p.author, p.author_id, 1, e.occurred_at
+			FROM events e
+			JOIN prs p ON p.number = e.pr_number
+			WHERE e.type = 'review_submitted'` + window + `
+		),
+		keyed AS (
+			SELECT *,
+				` + identityKey("s_id", "s_login") + ` as s_key,
+				` + identityKey("t_id", "t_login") + ` as t_key
+			FROM interactions
+			WHERE t_login IS NOT NULL AND kind = ANY($` + strconv.Itoa(kindsArg) + `)
+		),
+		edges AS (
+			SELECT
+				kind,
+				MAX(s_id) as s_id, (array_agg(s_login ORDER BY occurred_at DESC))[1] as s_login,
+				MAX(t_id) as t_id, (array_agg(t_login ORDER BY occurred_at DESC))[1] as t_login,
+				SUM(weight) as weight,
+				COUNT(*) as count,
+				MIN(occurred_at) as first_at,
+				MAX(occurred_at) as last_at
+			FROM keyed
+			WHERE s_key <> t_key
+			GROUP BY kind, s_key, t_key
+		)
+		SELECT
+			e.kind,
+			e.s_id, COALESCE(sl.login, e.s_login),
+			e.t_id, COALESCE(tl.login, e.t_login),
+			e.weight, e.count, e.first_at, e.last_at
+		FROM edges e
+		LEFT JOIN user_current_login sl ON sl.github_user_id = e.s_id
+		LEFT JOIN user_current_login tl ON tl.github_user_id = e.t_id
+		ORDER BY e.kind, e.s_id, e.t_id
+		LIMIT $` + strconv.Itoa(limitArg) + `
+	`
+
+	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return fmt.Errorf("failed to begin interaction graph read: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	truncated := false
+	for _, pass := range passes {
+		t, err := streamGraphEdges(ctx, tx, query, args, limit, pass)
+		if err != nil {
+			return err
+		}
+		truncated = truncated || t
+	}
+	if truncated {
+		return ErrGraphTruncated
+	}
+	return nil
+}
+
+// streamGraphEdges runs the graph query once, calling fn for each of the first
+// limit edges. Reports whether more edges were left unread.
+func streamGraphEdges(ctx context.Context, tx pgx.Tx, query string, args []interface{}, limit int, fn func(*GraphEdge) error) (bool, error) {
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return false, fmt.Errorf("failed to build interaction graph: %w", err)
+	}
+	defer rows.Close()
+
+	n := 0
+	for rows.Next() {
+		if n == limit {
+			return true, nil
+		}
+		n++
+		edge := &GraphEdge{}
+		err := rows.Scan(
+			&edge.Kind,
+			&edge.SourceID, &edge.SourceLogin,
+			&edge.TargetID, &edge.TargetLogin,
+			&edge.Weight, &edge.Count, &edge.FirstAt, &edge.LastAt,
+		)
+		if err != nil {
+			return false, fmt.Errorf("failed to scan graph edge: %w", err)
+		}
+		if err := fn(edge); err != nil {
+			return false, err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return false, fmt.Errorf("failed to read interaction graph: %w", err)
+	}
+	return false, nil
+}
@@ -0,0 +1,102 @@
+package feed
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// voterIdentity is the SQL grouping key for a person: the account ID, which
+// survives renames, falling back to the login for rows recorded without one
+const voterIdentity = `github_user_id, CASE WHEN github_user_id > 0 THEN '' ELSE github_user END`
+
+// identityKey is voterIdentity as one text key over an ID and a login column:
+// the account ID, or 'login:<login>' for rows recorded without one. The SQL
+// form of GraphNodeID.
+func identityKey(idColumn, loginColumn string) string {
+	return `CASE WHEN ` + idColumn + ` > 0 THEN ` + idColumn + `::text ELSE 'login:' || ` + loginColumn + ` END`
+}
+
+// voterKey is identityKey over an events row; alias is the table alias, or
+// empty for unqualified columns
+func voterKey(alias string) string {
+	if alias != "" {
+		alias += "."
+	}
+	return identityKey(alias+"github_user_id", alias+"github_user")
+}
+
+// sameVoter matches events (alias e) by the identity of a vote row (alias v)
+const sameVoter = `e.github_user_id = v.github_user_id AND (v.github_user_id > 0 OR e.github_user = v.github_user)`
+
+// userMatch returns a condition matching the events of a user, with its
+// argument for $argPos: by account ID once resolved (see lookupUserID), by
+// login otherwise. Both forms use an index (idx_events_github_user_id,
+// idx_events_github_user).
+func userMatch(userID int64, login string, argPos int) (string, interface{}) {
+	if userID > 0 {
+		return fmt.Sprintf("github_user_id = $%d", argPos), userID
+	}
+	return fmt.Sprintf("github_user = $%d", argPos), login
+}
+
+// lookupUserID returns the account that uses (or last used) a login, across
+// renames (user_aliases, migration 020), or 0 for a login never tied to an
+// account ID
+func (s *Store) lookupUserID(ctx context.Context, login string) (int64, error) {
+	var id int64
+	err := s.pool.QueryRow(ctx, `
+		SELECT github_user_id FROM user_aliases
+		WHERE LOWER(login) = LOWER($1)
+		ORDER BY last_seen_at DESC
+		LIMIT 1
+	`, login).Scan(&id)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to look up user: %w", err)
+	}
+	return id, nil
+}
+
+// ResolveUser resolves filters.GitHubUser to an account ID, so filterClause
+// and Matches follow the person across renames. The ListFilters queries call
+// it; stream subscribers call it before subscribing. A no-op without a user
+// filter or once resolved.
+func (s *Store) ResolveUser(ctx context.Context, filters *ListFilters) error {
+	if filters == nil || filters.GitHubUser == nil || filters.userResolved {
+		return nil
+	}
+	id, err := s.lookupUserID(ctx, *filters.GitHubUser)
+	if err != nil {
+		return err
+	}
+	filters.userID = id
+	filters.userResolved = true
+	return nil
+}
+
+// GetUserAliases returns every login seen for an account, oldest first
+func (s *Store) GetUserAliases(ctx context.Context, githubUserID int64) ([]string, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT login FROM user_aliases
+		WHERE github_user_id = $1
+		ORDER BY first_seen_at ASC
+	`, githubUserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user aliases: %w", err)
+	}
+	defer rows.Close()
+
+	aliases := []string{}
+	for rows.Next() {
+		var login string
+		if err := rows.Scan(&login); err != nil {
+			return nil, fmt.Errorf("failed to scan user alias: %w", err)
+		}
+		aliases = append(aliases, login)
+	}
+	return aliases, nil
+}
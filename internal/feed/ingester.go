@@ -5,6 +5,7 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"strconv"
@@ -17,7 +18,8 @@ import (
 
 // GraphQLClient defines the interface for GraphQL operations
 type GraphQLClient interface {
-	FetchDiscussions(ctx context.Context, owner, repo string) ([]github.Discussion, error)
+	FetchDiscussions(ctx context.Context, owner, repo string, since time.Time) ([]github.Discussion, error)
+	RateLimit() github.GraphQLRateLimit
 }
 
 // Ingester coordinates polling of GitHub APIs for event ingestion
@@ -25,6 +27,7 @@ type Ingester struct {
 	githubClient     *github.Client
 	graphqlClient    GraphQLClient
 	store            *Store
+	syncer           *Syncer // REST catch-up for Events API gaps
 	owner            string
 	repo             string
 	eventsInterval   time.Duration
@@ -33,8 +36,11 @@ type Ingester struct {
 
 	// State tracking
 	lastEventETag    string
+	lastSeenEvent    eventsCursor // Newest Events API event stored, for gap detection
 	openPRs          map[int]bool // Track which PRs are open for prioritized polling
+	openPRsDirty     bool         // openPRs changed since last persisted
 	reactionsCycle   int          // Counter for full-scan cadence (every 10th cycle polls all PRs)
+	discussionsCycle int          // Counter for full-scan cadence (every 12th cycle ignores the watermark)
 	mu               sync.RWMutex
 
 	// Status tracking for health endpoint
@@ -44,9 +50,12 @@ type Ingester struct {
 	eventsStatus        string
 	reactionsStatus     string
 	discussionsStatus   string
+	webhooksLastDelivery time.Time
+	webhooksStatus      string
 	statusMu            sync.RWMutex
 
 	// Lifecycle
+	gapFillCh        chan struct{} // Wakes gapFillLoop when a new gap is recorded
 	stopCh           chan struct{}
 	stopOnce         sync.Once
 	wg               sync.WaitGroup
@@ -66,16 +75,23 @@ func NewIngester(
 		return nil, fmt.Errorf("invalid repo format: %s (expected owner/repo)", ownerRepo)
 	}
 
+	syncer, err := NewSyncer(githubClient, graphqlClient, store, ownerRepo)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Ingester{
 		githubClient:     githubClient,
 		graphqlClient:    graphqlClient,
 		store:            store,
+		syncer:           syncer,
 		owner:            parts[0],
 		repo:             parts[1],
 		eventsInterval:   eventsInterval,
 		reactionsInterval: reactionsInterval,
 		discussionsInterval: discussionsInterval,
 		openPRs:          make(map[int]bool),
+		gapFillCh:        make(chan struct{}, 1),
 		stopCh:           make(chan struct{}),
 	}, nil
 }
@@ -90,6 +106,8 @@ func (ing *Ingester) Run(ctx context.Context) {
 		"discussions_interval", ing.discussionsInterval,
 	)
 
+	ing.loadState(ctx)
+
 	// Start Events API poller
 	ing.wg.Add(1)
 	go ing.pollEvents(ctx)
@@ -102,6 +120,10 @@ func (ing *Ingester) Run(ctx context.Context) {
 	ing.wg.Add(1)
 	go ing.pollDiscussions(ctx)
 
+	// Start Events API gap filler, kept off the events poll loop
+	ing.wg.Add(1)
+	go ing.gapFillLoop(ctx)
+
 	slog.Info("Ingester started - all pollers running")
 }
 
@@ -145,6 +167,8 @@ type IngesterStatus struct {
 	ReactionsStatus     string
 	DiscussionsLastPoll time.Time
 	DiscussionsStatus   string
+	WebhooksLastDelivery time.Time // Zero until the first webhook delivery
+	WebhooksStatus       string
 }
 
 // Status returns the current status of all ingesters
@@ -159,6 +183,8 @@ func (ing *Ingester) Status() *IngesterStatus {
 		ReactionsStatus:     ing.reactionsStatus,
 		DiscussionsLastPoll: ing.discussionsLastPoll,
 		DiscussionsStatus:   ing.discussionsStatus,
+		WebhooksLastDelivery: ing.webhooksLastDelivery,
+		WebhooksStatus:       ing.webhooksStatus,
 	}
 }
 
@@ -170,7 +196,9 @@ func (ing *Ingester) fetchAndProcessEvents(ctx context.Context) {
 	ing.eventsStatus = "running"
 	ing.statusMu.Unlock()
 
+	ing.mu.RLock()
 	etag := ing.lastEventETag
+	ing.mu.RUnlock()
 	events, headers, err := ing.githubClient.GetRepoEvents(ctx, ing.owner, ing.repo, &etag)
 	if err != nil {
 		slog.Error("Failed to fetch events", "error", err)
@@ -187,16 +215,7 @@ func (ing *Ingester) fetchAndProcessEvents(ctx context.Context) {
 		"etag_cached", events == nil,
 	)
 
-	if rateLimit.Remaining < 10 && !rateLimit.Reset.IsZero() {
-		sleepDur := time.Until(rateLimit.Reset)
-		if sleepDur > 0 && sleepDur < 15*time.Minute {
-			slog.Warn("GitHub rate limit low, backing off",
-				"remaining", rateLimit.Remaining,
-				"sleep", sleepDur.Round(time.Second),
-			)
-			time.Sleep(sleepDur)
-		}
-	}
+	backoffOnRateLimit(ctx, rateLimit)
 
 	// If 304 Not Modified, no new events
 	if events == nil {
@@ -204,15 +223,10 @@ func (ing *Ingester) fetchAndProcessEvents(ctx context.Context) {
 		return
 	}
 
-	// Update ETag for next request
-	newETag := headers.Get("ETag")
-	if newETag != "" {
-		ing.lastEventETag = newETag
-	}
-
 	// Process each event
 	processedCount := 0
 	dbErrors := 0
+	insertFailed := false
 	for _, rawEvent := range events {
 		// If we get multiple consecutive DB errors, stop processing this cycle
 		// to avoid burning through events while the DB is down
@@ -233,6 +247,7 @@ func (ing *Ingester) fetchAndProcessEvents(ctx context.Context) {
 			)
 			continue
 		}
+		ing.trackEntity(ctx, &rawEvent)
 
 		for _, feedEvent := range feedEvents {
 			if err := ing.store.Insert(ctx, feedEvent); err != nil {
@@ -242,6 +257,7 @@ func (ing *Ingester) fetchAndProcessEvents(ctx context.Context) {
 					"error", err,
 				)
 				dbErrors++
+				insertFailed = true
 				continue
 			}
 			dbErrors = 0 // Reset on success
@@ -249,6 +265,19 @@ func (ing *Ingester) fetchAndProcessEvents(ctx context.Context) {
 		}
 	}
 
+	// Update ETag for next request. Kept stale after a failed insert so the
+	// next poll gets a 200 and retries, rather than a 304 that hides the loss.
+	newETag := headers.Get("ETag")
+	if newETag != "" && !insertFailed {
+		ing.mu.Lock()
+		ing.lastEventETag = newETag
+		ing.mu.Unlock()
+		ing.saveState(ctx, stateEventsETag, newETag)
+	}
+	ing.saveOpenPRs(ctx)
+
+	ing.detectGap(ctx, events, !insertFailed)
+
 	if processedCount > 0 {
 		slog.Info("Events API processed",
 			"new_events", processedCount,
@@ -257,6 +286,27 @@ func (ing *Ingester) fetchAndProcessEvents(ctx context.Context) {
 	}
 }
 
+// backoffOnRateLimit sleeps until the REST rate limit resets when fewer than
+// 10 requests remain, unless the reset is implausibly far off. Shared by the
+// Events API poller and the user enricher, which draw on the same token.
+func backoffOnRateLimit(ctx context.Context, rateLimit *github.RateLimit) {
+	if rateLimit.Remaining >= 10 || rateLimit.Reset.IsZero() {
+		return
+	}
+	sleepDur := time.Until(rateLimit.Reset)
+	if sleepDur <= 0 || sleepDur >= 15*time.Minute {
+		return
+	}
+	slog.Warn("GitHub rate limit low, backing off",
+		"remaining", rateLimit.Remaining,
+		"sleep", sleepDur.Round(time.Second),
+	)
+	select {
+	case <-time.After(sleepDur):
+	case <-ctx.Done():
+	}
+}
+
 // parseGitHubEvent parses a raw GitHub event into feed event(s)
 func (ing *Ingester) parseGitHubEvent(ctx context.Context, raw *github.RawGitHubEvent) ([]*Event, error) {
 	events := []*Event{}
@@ -280,22 +330,65 @@ func (ing *Ingester) parseGitHubEvent(ctx context.Context, raw *github.RawGitHub
 			return nil, fmt.Errorf("failed to parse PullRequestEvent: %w", err)
 		}
 
+		// Edits fold into the PR's canonical event instead of a row of their own
+		if payload.Action == "edited" {
+			newTitle, newBody, previous, ok := previousVersion(payload.Changes, payload.PullRequest.Title, payload.PullRequest.Body, raw.CreatedAt)
+			if !ok {
+				return nil, nil
+			}
+			if err := ing.store.RecordPREdit(ctx, payload.Number, newTitle, newBody, previous); err != nil {
+				slog.Warn("Failed to record PR edit", "pr", payload.Number, "error", err)
+			} else {
+				slog.Info("PR edit recorded", "pr", payload.Number, "github_user", raw.Actor.Login)
+			}
+			return nil, nil
+		}
+
+		// Actions after opened are timed from the payload, which webhooks and
+		// the Events API agree on, and keyed by lifecycleSourceKey
+		at := payload.PullRequest.UpdatedAt
 		var eventType EventType
+		var subject string // Disambiguates actions that can repeat in the same second
 		switch payload.Action {
 		case "opened":
 			eventType = EventPROpened
 		case "closed":
 			if payload.PullRequest.Merged {
 				eventType = EventPRMerged
+				if payload.PullRequest.MergedAt != nil {
+					at = *payload.PullRequest.MergedAt
+				}
 			} else {
 				eventType = EventPRClosed
+				if payload.PullRequest.ClosedAt != nil {
+					at = *payload.PullRequest.ClosedAt
+				}
 			}
 		case "reopened":
 			eventType = EventPRReopened
-		case "edited":
-			eventType = EventPREdited
 		case "synchronize":
 			eventType = EventPRSynchronized
+		case "labeled":
+			eventType = EventPRLabeled
+			if payload.Label != nil {
+				subject = payload.Label.Name
+			}
+		case "assigned":
+			eventType = EventPRAssigned
+			if payload.Assignee != nil {
+				subject = payload.Assignee.Login
+			}
+		case "review_requested":
+			eventType = EventPRReviewRequested
+			if payload.RequestedReviewer != nil {
+				subject = payload.RequestedReviewer.Login
+			} else if payload.RequestedTeam != nil {
+				subject = "team/" + payload.RequestedTeam.Slug
+			}
+		case "converted_to_draft":
+			eventType = EventPRConvertedToDraft
+		case "ready_for_review":
+			eventType = EventPRReadyForReview
 		default:
 			return nil, nil // Unknown action, skip
 		}
@@ -304,24 +397,36 @@ func (ing *Ingester) parseGitHubEvent(ctx context.Context, raw *github.RawGitHub
 		if payload.Action == "opened" || payload.Action == "reopened" {
 			ing.mu.Lock()
 			ing.openPRs[payload.Number] = true
+			ing.openPRsDirty = true
 			ing.mu.Unlock()
 		} else if payload.Action == "closed" {
 			ing.mu.Lock()
 			delete(ing.openPRs, payload.Number)
+			ing.openPRsDirty = true
 			ing.mu.Unlock()
 		}
 
-		githubID := int64(payload.PullRequest.ID)
-		events = append(events, &Event{
+		event := &Event{
 			Type:         eventType,
 			GitHubUser:   raw.Actor.Login,
 			GitHubUserID: raw.Actor.ID,
 			PRNumber:     &payload.Number,
-			GitHubID:     &githubID,
 			Payload:      raw.Payload,
 			ContentHash:  computeContentHash(raw.Payload),
 			OccurredAt:   raw.CreatedAt,
-		})
+		}
+		if eventType == EventPROpened {
+			// The PR ID identifies only the opening; later actions share it
+			githubID := int64(payload.PullRequest.ID)
+			event.GitHubID = &githubID
+		} else {
+			if at.IsZero() {
+				at = raw.CreatedAt
+			}
+			event.OccurredAt = at
+			event.SourceKey = actionSourceKey("pr", payload.Number, eventType, subject, at)
+		}
+		events = append(events, event)
 
 	case "IssueCommentEvent":
 		var payload github.IssueCommentEventPayload
@@ -331,9 +436,10 @@ func (ing *Ingester) parseGitHubEvent(ctx context.Context, raw *github.RawGitHub
 
 		if payload.Action == "deleted" {
 			commentID := int64(payload.Comment.ID)
-			if err := ing.store.DeleteByCommentID(ctx, commentID); err != nil {
-				slog.Debug("Failed to delete comment (may not exist)", "comment_id", commentID, "error", err)
-			} else {
+			tombstone, err := ing.store.TombstoneComment(ctx, commentID, raw.Actor.Login, raw.Actor.ID, raw.CreatedAt)
+			if err != nil {
+				slog.Warn("Failed to record comment deletion", "comment_id", commentID, "error", err)
+			} else if tombstone != nil {
 				slog.Info("Comment deleted", "comment_id", commentID)
 			}
 			return nil, nil
@@ -401,22 +507,23 @@ func (ing *Ingester) parseGitHubEvent(ctx context.Context, raw *github.RawGitHub
 			return nil, fmt.Errorf("failed to parse PushEvent: %w", err)
 		}
 
+		head := payload.After
+		if head == "" {
+			// Events API uses "head" not "after" (webhooks use "after") — extract from raw JSON
+			var rawMap map[string]json.RawMessage
+			if json.Unmarshal(raw.Payload, &rawMap) == nil {
+				if h, ok := rawMap["head"]; ok {
+					var headStr string
+					json.Unmarshal(h, &headStr)
+					head = headStr
+				}
+			}
+		}
+
 		// GitHub Events API often omits commits from PushEvent payloads.
 		// Enrich with commits from the Compare API if missing.
 		enrichedPayload := raw.Payload
 		if len(payload.Commits) == 0 && payload.Before != "" && payload.Ref != "" {
-			head := payload.After
-			if head == "" {
-				// Events API uses "head" not "after" — extract from raw JSON
-				var rawMap map[string]json.RawMessage
-				if json.Unmarshal(raw.Payload, &rawMap) == nil {
-					if h, ok := rawMap["head"]; ok {
-						var headStr string
-						json.Unmarshal(h, &headStr)
-						head = headStr
-					}
-				}
-			}
 			if head != "" {
 				commits, err := ing.githubClient.GetCompareCommits(ctx, ing.owner, ing.repo, payload.Before, head)
 				if err != nil {
@@ -434,11 +541,19 @@ func (ing *Ingester) parseGitHubEvent(ctx context.Context, raw *github.RawGitHub
 			}
 		}
 
+		// ref+head identifies a push across Events API and webhook payload shapes
+		var sourceKey *string
+		if payload.Ref != "" && head != "" {
+			key := "push:" + payload.Ref + ":" + head
+			sourceKey = &key
+		}
+
 		events = append(events, &Event{
 			Type:         EventPush,
 			GitHubUser:   raw.Actor.Login,
 			GitHubUserID: raw.Actor.ID,
 			GitHubID:     rawEventID,
+			SourceKey:    sourceKey,
 			Payload:      enrichedPayload,
 			ContentHash:  computeContentHash(enrichedPayload),
 			OccurredAt:   raw.CreatedAt,
@@ -480,32 +595,66 @@ func (ing *Ingester) parseGitHubEvent(ctx context.Context, raw *github.RawGitHub
 			return nil, fmt.Errorf("failed to parse IssuesEvent: %w", err)
 		}
 
+		// Timed and keyed like PR actions (see PullRequestEvent)
+		at := payload.Issue.UpdatedAt
 		var eventType EventType
+		var subject string
 		switch payload.Action {
 		case "opened":
 			eventType = EventIssueOpened
 		case "closed":
 			eventType = EventIssueClosed
+			if payload.Issue.ClosedAt != nil {
+				at = *payload.Issue.ClosedAt
+			}
 		case "reopened":
 			eventType = EventIssueReopened
+		case "labeled":
+			eventType = EventIssueLabeled
+			if payload.Label != nil {
+				subject = payload.Label.Name
+			}
+		case "locked":
+			eventType = EventIssueLocked
+		case "transferred":
+			eventType = EventIssueTransferred
 		case "edited":
-			eventType = EventIssueEdited
+			// Folded into the issue's canonical event, like PR edits
+			newTitle, newBody, previous, ok := previousVersion(payload.Changes, payload.Issue.Title, payload.Issue.Body, raw.CreatedAt)
+			if !ok {
+				return nil, nil
+			}
+			if err := ing.store.RecordIssueEdit(ctx, payload.Issue.Number, newTitle, newBody, previous); err != nil {
+				slog.Warn("Failed to record issue edit", "issue", payload.Issue.Number, "error", err)
+			} else {
+				slog.Info("Issue edit recorded", "issue", payload.Issue.Number, "github_user", raw.Actor.Login)
+			}
+			return nil, nil
 		default:
 			return nil, nil
 		}
 
-		githubID := int64(payload.Issue.ID)
-		events = append(events, &Event{
+		event := &Event{
 			Type:         eventType,
 			GitHubUser:   raw.Actor.Login,
 			GitHubUserID: raw.Actor.ID,
 
 			IssueNumber:  &payload.Issue.Number,
-			GitHubID:     &githubID,
 			Payload:      raw.Payload,
 			ContentHash:  computeContentHash(raw.Payload),
 			OccurredAt:   raw.CreatedAt,
-		})
+		}
+		if eventType == EventIssueOpened {
+			githubID := int64(payload.Issue.ID)
+			event.GitHubID = &githubID
+		} else {
+			if at.IsZero() {
+				at = raw.CreatedAt
+			}
+			event.OccurredAt = at
+			event.SourceKey = actionSourceKey("issue", payload.Issue.Number, eventType, subject, at)
+		}
+		events = append(events, event)
 
 	case "PullRequestReviewEvent":
 		var payload github.PullRequestReviewEventPayload
@@ -513,7 +662,28 @@ func (ing *Ingester) parseGitHubEvent(ctx context.Context, raw *github.RawGitHub
 			return nil, fmt.Errorf("failed to parse PullRequestReviewEvent: %w", err)
 		}
 
-		if payload.Action != "submitted" {
+		switch payload.Action {
+		case "dismissed", "edited":
+			// The review ID identifies the submission; follow-ups get their own key
+			eventType := EventReviewDismissed
+			sourceKey := fmt.Sprintf("review:%d:dismissed", payload.Review.ID)
+			if payload.Action == "edited" {
+				eventType = EventReviewEdited
+				sourceKey = fmt.Sprintf("review:%d:edited:%s", payload.Review.ID, computeContentHash([]byte(payload.Review.Body))[:16])
+			}
+			events = append(events, &Event{
+				Type:         eventType,
+				GitHubUser:   raw.Actor.Login,
+				GitHubUserID: raw.Actor.ID,
+				PRNumber:     &payload.PullRequest.Number,
+				SourceKey:    &sourceKey,
+				Payload:      raw.Payload,
+				ContentHash:  computeContentHash(raw.Payload),
+				OccurredAt:   raw.CreatedAt,
+			})
+			return events, nil
+		case "submitted":
+		default:
 			return nil, nil
 		}
 
@@ -538,9 +708,10 @@ func (ing *Ingester) parseGitHubEvent(ctx context.Context, raw *github.RawGitHub
 
 		if payload.Action == "deleted" {
 			commentID := int64(payload.Comment.ID)
-			if err := ing.store.DeleteByCommentID(ctx, commentID); err != nil {
-				slog.Debug("Failed to delete review comment (may not exist)", "comment_id", commentID, "error", err)
-			} else {
+			tombstone, err := ing.store.TombstoneComment(ctx, commentID, raw.Actor.Login, raw.Actor.ID, raw.CreatedAt)
+			if err != nil {
+				slog.Warn("Failed to record review comment deletion", "comment_id", commentID, "error", err)
+			} else if tombstone != nil {
 				slog.Info("Review comment deleted", "comment_id", commentID)
 			}
 			return nil, nil
@@ -729,12 +900,42 @@ func (ing *Ingester) parseGitHubEvent(ctx context.Context, raw *github.RawGitHub
 			return nil, fmt.Errorf("failed to parse DiscussionEvent: %w", err)
 		}
 
+		discussionNumber := payload.Discussion.Number
 		if payload.Action != "created" {
-			return nil, nil
+			var eventType EventType
+			switch payload.Action {
+			case "answered":
+				eventType = EventDiscussionAnswered
+			case "unanswered":
+				eventType = EventDiscussionUnanswered
+			case "closed":
+				eventType = EventDiscussionClosed
+			case "locked":
+				eventType = EventDiscussionLocked
+			case "category_changed":
+				eventType = EventDiscussionCategoryChanged
+			default:
+				return nil, nil
+			}
+
+			at := payload.Discussion.UpdatedAt
+			if at.IsZero() {
+				at = raw.CreatedAt
+			}
+			events = append(events, &Event{
+				Type:             eventType,
+				GitHubUser:       raw.Actor.Login,
+				GitHubUserID:     raw.Actor.ID,
+				DiscussionNumber: &discussionNumber,
+				SourceKey:        lifecycleSourceKey("discussion", discussionNumber, eventType, at),
+				Payload:          raw.Payload,
+				ContentHash:      computeContentHash(raw.Payload),
+				OccurredAt:       at,
+			})
+			return events, nil
 		}
 
 		githubID := int64(payload.Discussion.ID)
-		discussionNumber := payload.Discussion.Number
 		events = append(events, &Event{
 			Type:             EventDiscussionCreated,
 			GitHubUser:       payload.Discussion.User.Login,
@@ -742,6 +943,7 @@ func (ing *Ingester) parseGitHubEvent(ctx context.Context, raw *github.RawGitHub
 
 			DiscussionNumber: &discussionNumber,
 			GitHubID:         &githubID,
+			SourceKey:        nodeSourceKey(payload.Discussion.NodeID),
 			Payload:          raw.Payload,
 			ContentHash:      computeContentHash(raw.Payload),
 			OccurredAt:       payload.Discussion.CreatedAt,
@@ -790,6 +992,34 @@ func NormalizeReactionContent(content string) string {
 	return content // Already in REST format or unknown
 }
 
+// previousVersion reconstructs a PR or issue as it was before an edited action
+// from the payload's changes and its current title and body. newTitle/newBody
+// are the edited values (nil if unchanged). ok is false if neither changed.
+func previousVersion(changes github.EditChanges, title, body string, editedAt time.Time) (newTitle, newBody *string, previous EditHistoryEntry, ok bool) {
+	if changes.Title == nil && changes.Body == nil {
+		return nil, nil, EditHistoryEntry{}, false
+	}
+
+	previous = EditHistoryEntry{Title: title, Body: body, EditedAt: editedAt}
+	if changes.Title != nil {
+		newTitle = &title
+		previous.Title = changes.Title.From
+	}
+	if changes.Body != nil {
+		newBody = &body
+		previous.Body = changes.Body.From
+	}
+	return newTitle, newBody, previous, true
+}
+
+// strPtrValue dereferences an optional string for logging
+func strPtrValue(p *string) string {
+	if p == nil {
+		return ""
+	}
+	return *p
+}
+
 // computeContentHash computes SHA256 hash of payload for deduplication
 func computeContentHash(payload []byte) string {
 	hash := sha256.Sum256(payload)
@@ -818,9 +1048,19 @@ func (ing *Ingester) pollReactions(ctx context.Context) {
 	}
 }
 
-// fetchAndProcessReactions fetches reactions for all PRs (THE VOTES!)
-// Open PRs are polled every cycle. All PRs (including closed/merged) are
-// polled every 10th cycle to capture late votes without burning rate limit.
+// Comment reaction windows: comments created within these windows (by their
+// issue_comment event) have their reactions polled. Older comments rarely
+// gain reactions, and every comment costs one API call.
+const (
+	activeCommentWindow   = 7 * 24 * time.Hour  // Every cycle
+	fullScanCommentWindow = 90 * 24 * time.Hour // Full-scan cycles
+)
+
+// fetchAndProcessReactions fetches reactions for PRs (THE VOTES!), issues and
+// recently active comments. Open PRs and issues and comments from the last
+// week are polled every cycle. All PRs and issues (including closed/merged),
+// plus comments from the last 90 days, are polled every 10th cycle to capture
+// late votes without burning rate limit.
 func (ing *Ingester) fetchAndProcessReactions(ctx context.Context) {
 	// Update status
 	ing.statusMu.Lock()
@@ -832,99 +1072,113 @@ func (ing *Ingester) fetchAndProcessReactions(ctx context.Context) {
 	ing.reactionsCycle++
 	cycle := ing.reactionsCycle
 	ing.mu.Unlock()
+	ing.saveState(ctx, stateReactionsCycle, cycle)
 
-	// Every 10th cycle, poll ALL PRs (open + closed) to catch late votes
+	// Every 10th cycle, poll ALL PRs and issues (open + closed) to catch late votes
 	pollAll := cycle%10 == 0
 
-	var prNumbers []int
+	setError := func(err error) {
+		ing.statusMu.Lock()
+		ing.reactionsStatus = "error: " + err.Error()
+		ing.statusMu.Unlock()
+	}
+
+	var targets []ReactionTarget
+	prCount, issueCount := 0, 0
+	commentWindow := activeCommentWindow
 
 	if pollAll {
 		allPRs, err := ing.githubClient.GetAllPRs(ctx, ing.owner, ing.repo)
 		if err != nil {
 			slog.Error("Failed to fetch all PRs for reactions", "error", err)
-			ing.statusMu.Lock()
-			ing.reactionsStatus = "error: " + err.Error()
-			ing.statusMu.Unlock()
+			setError(err)
 			return
 		}
 		for _, pr := range allPRs {
-			prNumbers = append(prNumbers, pr.Number)
+			targets = append(targets, PRReactions(pr.Number))
 		}
-		slog.Info("Reactions: full PR scan", "total_prs", len(prNumbers))
+		prCount = len(allPRs)
+
+		allIssues, err := ing.githubClient.GetAllIssues(ctx, ing.owner, ing.repo)
+		if err != nil {
+			slog.Error("Failed to fetch all issues for reactions", "error", err)
+			setError(err)
+			return
+		}
+		for _, issue := range allIssues {
+			targets = append(targets, IssueReactions(issue.Number))
+		}
+		issueCount = len(allIssues)
+
+		commentWindow = fullScanCommentWindow
+		slog.Info("Reactions: full scan", "total_prs", prCount, "total_issues", issueCount)
 	} else {
 		prs, err := ing.githubClient.GetOpenPRs(ctx, ing.owner, ing.repo)
 		if err != nil {
 			slog.Error("Failed to fetch open PRs for reactions", "error", err)
-			ing.statusMu.Lock()
-			ing.reactionsStatus = "error: " + err.Error()
-			ing.statusMu.Unlock()
+			setError(err)
 			return
 		}
 		for _, pr := range prs {
-			prNumbers = append(prNumbers, pr.Number)
+			targets = append(targets, PRReactions(pr.Number))
 		}
+		prCount = len(prs)
+
+		issues, err := ing.githubClient.GetOpenIssues(ctx, ing.owner, ing.repo)
+		if err != nil {
+			slog.Error("Failed to fetch open issues for reactions", "error", err)
+			setError(err)
+			return
+		}
+		for _, issue := range issues {
+			targets = append(targets, IssueReactions(issue.Number))
+		}
+		issueCount = len(issues)
+	}
+
+	commentIDs, err := ing.store.ListCommentIDs(ctx, time.Now().Add(-commentWindow))
+	if err != nil {
+		slog.Error("Failed to list comments for reactions", "error", err)
+		setError(err)
+		return
+	}
+	for _, id := range commentIDs {
+		targets = append(targets, CommentReactions(id))
 	}
 
 	totalReactions := 0
+	totalRetracted := 0
 	dbErrors := 0
-	for _, prNum := range prNumbers {
+	for i, target := range targets {
 		if dbErrors >= 3 {
 			slog.Warn("Stopping reaction processing due to repeated DB errors",
 				"db_errors", dbErrors,
-				"prs_remaining", len(prNumbers),
+				"targets_remaining", len(targets)-i,
 			)
 			break
 		}
 
-		reactions, err := ing.githubClient.GetIssueReactions(ctx, ing.owner, ing.repo, prNum)
-		if err != nil {
-			slog.Error("Failed to fetch reactions for PR",
-				"pr_number", prNum,
+		var reactions []github.DetailedReaction
+		var err error
+		if target.Kind == "comment" {
+			reactions, err = ing.githubClient.GetCommentReactions(ctx, ing.owner, ing.repo, target.CommentID)
+		} else {
+			reactions, err = ing.githubClient.GetIssueReactions(ctx, ing.owner, ing.repo, target.Number)
+		}
+		truncated := errors.Is(err, github.ErrReactionsTruncated)
+		if err != nil && !truncated {
+			slog.Error("Failed to fetch reactions",
+				"target", target.String(),
 				"error", err,
 			)
 			continue
 		}
 
 		for _, reaction := range reactions {
-			// Determine choice for votes (+1/-1)
-			var choice *int8
-			if reaction.Content == "+1" {
-				c := int8(1)
-				choice = &c
-			} else if reaction.Content == "-1" {
-				c := int8(-1)
-				choice = &c
-			}
-
-			// Create reaction payload for storage
-			reactionPayload, _ := json.Marshal(map[string]interface{}{
-				"id":         reaction.ID,
-				"content":    reaction.Content,
-				"user":       reaction.User,
-				"created_at": reaction.CreatedAt,
-				"pr_number":  prNum,
-			})
-
-			githubID := reaction.ID
-			prNumber := prNum
-			reactionType := reaction.Content
-			event := &Event{
-				Type:         EventReaction,
-				GitHubUser:   reaction.User.Login,
-				GitHubUserID: reaction.User.ID,
-
-				PRNumber:     &prNumber,
-				Choice:       choice,
-				ReactionType: &reactionType,
-				GitHubID:     &githubID,
-				Payload:      reactionPayload,
-				ContentHash:  computeContentHash(reactionPayload),
-				OccurredAt:   reaction.CreatedAt,
-			}
-
+			event := ReactionEvent(reaction, target)
 			if err := ing.store.Insert(ctx, event); err != nil {
 				slog.Error("Failed to insert reaction",
-					"pr_number", prNum,
+					"target", target.String(),
 					"reaction_id", reaction.ID,
 					"error", err,
 				)
@@ -934,11 +1188,46 @@ func (ing *Ingester) fetchAndProcessReactions(ctx context.Context) {
 			dbErrors = 0
 			totalReactions++
 		}
+
+		// A truncated set would retract every stored reaction past the cap
+		if truncated {
+			slog.Warn("Reactions truncated, skipping retraction check",
+				"target", target.String(),
+				"fetched", len(reactions),
+			)
+			continue
+		}
+
+		// Reconcile: any stored reaction missing from GitHub's current set was retracted
+		currentIDs := make([]int64, 0, len(reactions))
+		for _, reaction := range reactions {
+			currentIDs = append(currentIDs, reaction.ID)
+		}
+		removed, err := ing.store.RetractMissingReactions(ctx, target, currentIDs, time.Now())
+		if err != nil {
+			slog.Error("Failed to reconcile reactions",
+				"target", target.String(),
+				"error", err,
+			)
+			dbErrors++
+			continue
+		}
+		for _, r := range removed {
+			slog.Info("Reaction retracted",
+				"target", target.String(),
+				"github_user", r.GitHubUser,
+				"reaction_type", strPtrValue(r.ReactionType),
+			)
+		}
+		totalRetracted += len(removed)
 	}
 
 	slog.Info("Reactions API processed",
-		"prs_checked", len(prNumbers),
+		"prs_checked", prCount,
+		"issues_checked", issueCount,
+		"comments_checked", len(commentIDs),
 		"reactions_processed", totalReactions,
+		"reactions_retracted", totalRetracted,
 		"full_scan", pollAll,
 	)
 }
@@ -981,115 +1270,94 @@ func (ing *Ingester) fetchAndProcessDiscussions(ctx context.Context) {
 		return
 	}
 
-	discussions, err := ing.graphqlClient.FetchDiscussions(ctx, ing.owner, ing.repo)
-	if err != nil {
-		slog.Error("Failed to fetch discussions", "error", err)
+	ing.mu.Lock()
+	ing.discussionsCycle++
+	cycle := ing.discussionsCycle
+	ing.mu.Unlock()
+	ing.saveState(ctx, stateDiscussionsCycle, cycle)
+
+	// Only walk discussions updated since the last completed poll. Reactions
+	// don't bump a discussion's updatedAt, so every 12th cycle rescans all.
+	var watermark discussionsWatermark
+	if _, err := ing.store.LoadState(ctx, stateDiscussionsWatermark, &watermark); err != nil {
+		slog.Warn("Failed to load discussions watermark, doing full scan", "error", err)
+	}
+	since := watermark.UpdatedAt
+	if cycle%12 == 0 {
+		since = time.Time{}
+		slog.Info("Discussions: full scan")
+	}
+
+	costBefore := ing.graphqlClient.RateLimit().TotalCost
+	discussions, fetchErr := ing.graphqlClient.FetchDiscussions(ctx, ing.owner, ing.repo, since)
+	if fetchErr != nil {
+		slog.Error("Failed to fetch discussions",
+			"error", fetchErr,
+			"partial_discussions", len(discussions),
+		)
 		ing.statusMu.Lock()
-		ing.discussionsStatus = "error: " + err.Error()
+		ing.discussionsStatus = "error: " + fetchErr.Error()
 		ing.statusMu.Unlock()
-		return
+		if len(discussions) == 0 {
+			return
+		}
+		// Store what was fetched, but leave the watermark for a retry
+	}
+
+	claim, err := ing.store.HasUnclaimedDiscussionEvents(ctx)
+	if err != nil {
+		slog.Warn("Failed to check for unclaimed discussion events", "error", err)
+		claim = true
 	}
 
 	totalEvents := 0
+	dbErrors := 0
 	for _, discussion := range discussions {
-		// Discussion creation event
-		discussionPayload, _ := json.Marshal(discussion)
-
-		discussionNumber := discussion.Number
-		discussionID := int64(discussion.Number) // Use number as ID for deduping
-		event := &Event{
-			Type:             EventDiscussionCreated,
-			GitHubUser:       discussion.Author.Login,
-			GitHubUserID:     0, // GraphQL doesn't return user ID easily
-
-			DiscussionNumber: &discussionNumber,
-			GitHubID:         &discussionID,
-			Payload:          discussionPayload,
-			ContentHash:      computeContentHash(discussionPayload),
-			OccurredAt:       discussion.CreatedAt,
-		}
-
-		if err := ing.store.Insert(ctx, event); err != nil {
-			slog.Error("Failed to insert discussion event",
-				"discussion_number", discussion.Number,
-				"error", err,
-			)
-		} else {
-			totalEvents++
-		}
-
-		// Discussion comments
-		for _, comment := range discussion.Comments {
-			commentPayload, _ := json.Marshal(comment)
-
-			commentID := int64(comment.Number) // Use comment number as ID
-			commentEvent := &Event{
-				Type:             EventDiscussionComment,
-				GitHubUser:       comment.Author.Login,
-				GitHubUserID:     0,
-				DiscussionNumber: &discussionNumber,
-				CommentID:        &commentID,
-				GitHubID:         &commentID,
-				Payload:          commentPayload,
-				ContentHash:      computeContentHash(commentPayload),
-				OccurredAt:       comment.CreatedAt,
-			}
-
-			if err := ing.store.Insert(ctx, commentEvent); err != nil {
-				slog.Error("Failed to insert discussion comment",
+		for _, event := range DiscussionEvents(discussion) {
+			inserted, err := ing.store.InsertDiscussionEvent(ctx, event, claim)
+			if err != nil {
+				slog.Error("Failed to insert discussion event",
 					"discussion_number", discussion.Number,
-					"comment_id", comment.Number,
+					"type", event.Type,
+					"source_key", strPtrValue(event.SourceKey),
 					"error", err,
 				)
-			} else {
+				dbErrors++
+				continue
+			}
+			if inserted {
 				totalEvents++
 			}
 		}
+	}
 
-		// Discussion reactions
-		for _, reaction := range discussion.Reactions {
-			// Normalize GraphQL uppercase types (THUMBS_UP → +1) before storage
-			reaction.Content = NormalizeReactionContent(reaction.Content)
-			reactionPayload, _ := json.Marshal(reaction)
-
-			var choice *int8
-			if reaction.Content == "+1" {
-				c := int8(1)
-				choice = &c
-			} else if reaction.Content == "-1" {
-				c := int8(-1)
-				choice = &c
-			}
-
-			reactionID := int64(reaction.Number) // Use reaction number as ID
-			reactionType := reaction.Content
-			reactionEvent := &Event{
-				Type:             EventReaction,
-				GitHubUser:       reaction.User.Login,
-				GitHubUserID:     0,
-				DiscussionNumber: &discussionNumber,
-				Choice:           choice,
-				ReactionType:     &reactionType,
-				GitHubID:         &reactionID,
-				Payload:          reactionPayload,
-				ContentHash:      computeContentHash(reactionPayload),
-				OccurredAt:       reaction.CreatedAt,
+	// Advance only after a complete walk: discussions arrive newest-updated
+	// first, so a partial result would otherwise skip the older remainder
+	if fetchErr == nil && dbErrors == 0 {
+		next := watermark
+		for _, discussion := range discussions {
+			if discussion.UpdatedAt.After(next.UpdatedAt) {
+				next.UpdatedAt = discussion.UpdatedAt
 			}
-
-			if err := ing.store.Insert(ctx, reactionEvent); err != nil {
-				slog.Error("Failed to insert discussion reaction",
-					"discussion_number", discussion.Number,
-					"reaction_id", reaction.Number,
-					"error", err,
-				)
-			} else {
-				totalEvents++
+		}
+		if next.UpdatedAt.After(watermark.UpdatedAt) {
+			if err := ing.store.SaveState(ctx, stateDiscussionsWatermark, next); err != nil {
+				slog.Error("Failed to save discussions watermark", "error", err)
 			}
 		}
 	}
 
+	rate := ing.graphqlClient.RateLimit()
 	slog.Info("Discussions GraphQL processed",
 		"discussions_fetched", len(discussions),
+		"since", since,
 		"total_events", totalEvents,
+		"graphql_cost", rate.TotalCost-costBefore,
+		"graphql_remaining", rate.Remaining,
 	)
 }
+
+// discussionsWatermark is the max discussion updatedAt seen by a complete poll
+type discussionsWatermark struct {
+	UpdatedAt time.Time `json:"updatedAt"`
+}
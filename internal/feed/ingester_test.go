@@ -0,0 +1,263 @@
+package feed
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/skridlevsky/openchaos-feed/internal/github"
+)
+
+// Payloads under testdata/ follow GitHub's Events API (events/) and webhook
+// (webhooks/) shapes, trimmed to the fields the parser and frontend read.
+
+func TestParseGitHubEvent_Actions(t *testing.T) {
+	tests := []struct {
+		name      string
+		file      string // testdata/events/<file>.json, or testdata/webhooks/<file>.json with webhook set
+		webhook   string // Webhook event name; empty for Events API payloads
+		wantType  EventType
+		wantUser  string
+		wantPR    int
+		wantIssue int
+		wantDisc  int
+		wantKey   string
+		wantAt    string
+	}{
+		// Lifecycle actions after opened must not reuse the PR/issue ID as github_id
+		{name: "pr closed", file: "pr_closed", wantType: EventPRClosed, wantUser: "alice", wantPR: 142,
+			wantKey: "pr:142:pr_closed:1772454605", wantAt: "2026-03-02T12:30:05Z"},
+		{name: "pr merged", file: "pr_merged", wantType: EventPRMerged, wantUser: "alice", wantPR: 142,
+			wantKey: "pr:142:pr_merged:1772454605", wantAt: "2026-03-02T12:30:05Z"},
+		{name: "pr labeled", file: "pr_labeled", wantType: EventPRLabeled, wantUser: "alice", wantPR: 142,
+			wantKey: "pr:142:pr_labeled:governance:1772454605"},
+		{name: "pr assigned", file: "pr_assigned", wantType: EventPRAssigned, wantUser: "alice", wantPR: 142,
+			wantKey: "pr:142:pr_assigned:carol:1772454605"},
+		{name: "pr review requested from user", file: "pr_review_requested", wantType: EventPRReviewRequested, wantUser: "alice", wantPR: 142,
+			wantKey: "pr:142:pr_review_requested:dave:1772454605"},
+		{name: "pr review requested from team", file: "pr_review_requested_team", wantType: EventPRReviewRequested, wantUser: "alice", wantPR: 142,
+			wantKey: "pr:142:pr_review_requested:team/maintainers:1772454605"},
+		{name: "pr converted to draft", file: "pr_converted_to_draft", wantType: EventPRConvertedToDraft, wantUser: "alice", wantPR: 142,
+			wantKey: "pr:142:pr_converted_to_draft:1772454605"},
+		{name: "pr ready for review", file: "pr_ready_for_review", wantType: EventPRReadyForReview, wantUser: "alice", wantPR: 142,
+			wantKey: "pr:142:pr_ready_for_review:1772454605"},
+
+		{name: "review dismissed", file: "review_dismissed", wantType: EventReviewDismissed, wantUser: "alice", wantPR: 142,
+			wantKey: "review:3120045511:dismissed"},
+		{name: "review edited", file: "review_edited", wantType: EventReviewEdited, wantUser: "alice", wantPR: 142},
+
+		{name: "issue closed", file: "issue_closed", wantType: EventIssueClosed, wantUser: "alice", wantIssue: 57,
+			wantKey: "issue:57:issue_closed:1772439342", wantAt: "2026-03-02T08:15:42Z"},
+		{name: "issue labeled", file: "issue_labeled", wantType: EventIssueLabeled, wantUser: "alice", wantIssue: 57,
+			wantKey: "issue:57:issue_labeled:bug:1772439342"},
+		{name: "issue locked", file: "issue_locked", wantType: EventIssueLocked, wantUser: "alice", wantIssue: 57,
+			wantKey: "issue:57:issue_locked:1772439342"},
+		{name: "issue transferred", file: "issue_transferred", wantType: EventIssueTransferred, wantUser: "alice", wantIssue: 57,
+			wantKey: "issue:57:issue_transferred:1772439342"},
+
+		{name: "discussion answered", file: "discussion_answered", webhook: "discussion", wantType: EventDiscussionAnswered, wantUser: "alice", wantDisc: 12,
+			wantKey: "discussion:12:discussion_answered:1772530887", wantAt: "2026-03-03T09:41:27Z"},
+		{name: "discussion unanswered", file: "discussion_unanswered", webhook: "discussion", wantType: EventDiscussionUnanswered, wantUser: "alice", wantDisc: 12,
+			wantKey: "discussion:12:discussion_unanswered:1772532131"},
+		{name: "discussion closed", file: "discussion_closed", webhook: "discussion", wantType: EventDiscussionClosed, wantUser: "alice", wantDisc: 12,
+			wantKey: "discussion:12:discussion_closed:1772607600"},
+		{name: "discussion locked", file: "discussion_locked", webhook: "discussion", wantType: EventDiscussionLocked, wantUser: "alice", wantDisc: 12,
+			wantKey: "discussion:12:discussion_locked:1772607900"},
+		{name: "discussion category changed", file: "discussion_category_changed", webhook: "discussion", wantType: EventDiscussionCategoryChanged, wantUser: "alice", wantDisc: 12,
+			wantKey: "discussion:12:discussion_category_changed:1772468400"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ing := &Ingester{openPRs: make(map[int]bool)}
+			raw := loadRawEvent(t, tt.file, tt.webhook)
+
+			events, err := ing.parseGitHubEvent(context.Background(), raw)
+			if err != nil {
+				t.Fatalf("parseGitHubEvent: %v", err)
+			}
+			if len(events) != 1 {
+				t.Fatalf("got %d events, want 1", len(events))
+			}
+			event := events[0]
+
+			if event.Type != tt.wantType {
+				t.Errorf("Type = %q, want %q", event.Type, tt.wantType)
+			}
+			if event.GitHubUser != tt.wantUser {
+				t.Errorf("GitHubUser = %q, want %q", event.GitHubUser, tt.wantUser)
+			}
+			checkNumber(t, "PRNumber", event.PRNumber, tt.wantPR)
+			checkNumber(t, "IssueNumber", event.IssueNumber, tt.wantIssue)
+			checkNumber(t, "DiscussionNumber", event.DiscussionNumber, tt.wantDisc)
+
+			if event.GitHubID != nil {
+				t.Errorf("GitHubID = %d, want nil (collides with the opening event)", *event.GitHubID)
+			}
+			if event.SourceKey == nil {
+				t.Fatal("SourceKey = nil, want a dedup key")
+			}
+			if tt.wantKey != "" && *event.SourceKey != tt.wantKey {
+				t.Errorf("SourceKey = %q, want %q", *event.SourceKey, tt.wantKey)
+			}
+			if tt.wantAt != "" {
+				want, _ := time.Parse(time.RFC3339, tt.wantAt)
+				if !event.OccurredAt.Equal(want) {
+					t.Errorf("OccurredAt = %s, want %s", event.OccurredAt, want)
+				}
+			}
+			if event.ContentHash != computeContentHash(raw.Payload) {
+				t.Error("ContentHash doesn't match payload")
+			}
+		})
+	}
+}
+
+// TestParseGitHubEvent_ReviewEditsDistinct checks that successive edits of a
+// review get distinct keys while redeliveries of one edit share a key
+func TestParseGitHubEvent_ReviewEditsDistinct(t *testing.T) {
+	ing := &Ingester{openPRs: make(map[int]bool)}
+	raw := loadRawEvent(t, "review_edited", "")
+
+	first, err := ing.parseGitHubEvent(context.Background(), raw)
+	if err != nil {
+		t.Fatalf("parseGitHubEvent: %v", err)
+	}
+	again, _ := ing.parseGitHubEvent(context.Background(), raw)
+	if *first[0].SourceKey != *again[0].SourceKey {
+		t.Errorf("redelivery key %q != %q", *again[0].SourceKey, *first[0].SourceKey)
+	}
+
+	var payload map[string]interface{}
+	json.Unmarshal(raw.Payload, &payload)
+	payload["review"].(map[string]interface{})["body"] = "Needs a quorum rule and a deadline."
+	raw.Payload, _ = json.Marshal(payload)
+
+	second, _ := ing.parseGitHubEvent(context.Background(), raw)
+	if *first[0].SourceKey == *second[0].SourceKey {
+		t.Errorf("second edit reused key %q", *first[0].SourceKey)
+	}
+}
+
+// TestParseGitHubEvent_IgnoredActions checks actions with no feed event are skipped
+func TestParseGitHubEvent_IgnoredActions(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		webhook string
+		action  string
+	}{
+		{name: "pr unlabeled", file: "pr_labeled", action: "unlabeled"},
+		{name: "issue unlocked", file: "issue_locked", action: "unlocked"},
+		{name: "discussion pinned", file: "discussion_locked", webhook: "discussion", action: "pinned"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ing := &Ingester{openPRs: make(map[int]bool)}
+			raw := loadRawEvent(t, tt.file, tt.webhook)
+
+			var payload map[string]interface{}
+			json.Unmarshal(raw.Payload, &payload)
+			payload["action"] = tt.action
+			raw.Payload, _ = json.Marshal(payload)
+
+			events, err := ing.parseGitHubEvent(context.Background(), raw)
+			if err != nil {
+				t.Fatalf("parseGitHubEvent: %v", err)
+			}
+			if len(events) != 0 {
+				t.Errorf("got %d events, want 0", len(events))
+			}
+		})
+	}
+}
+
+// TestWebhookToRawEvent_OccurredAt checks deliveries are timestamped from
+// their payload, not the delivery time
+func TestWebhookToRawEvent_OccurredAt(t *testing.T) {
+	tests := []struct {
+		name      string
+		eventType string
+		body      string
+		wantAt    string // Empty for now
+	}{
+		{name: "review", eventType: "PullRequestReviewEvent",
+			body:   `{"action":"submitted","review":{"submitted_at":"2026-03-02T10:00:00Z"},"pull_request":{"updated_at":"2026-03-02T11:00:00Z"}}`,
+			wantAt: "2026-03-02T10:00:00Z"},
+		{name: "comment", eventType: "IssueCommentEvent",
+			body:   `{"action":"created","comment":{"created_at":"2026-03-02T09:00:00Z"},"issue":{"updated_at":"2026-03-02T09:00:01Z"}}`,
+			wantAt: "2026-03-02T09:00:00Z"},
+		{name: "pull request", eventType: "PullRequestEvent",
+			body:   `{"action":"closed","pull_request":{"updated_at":"2026-03-02T12:30:05Z"}}`,
+			wantAt: "2026-03-02T12:30:05Z"},
+		{name: "issue", eventType: "IssuesEvent",
+			body:   `{"action":"closed","issue":{"updated_at":"2026-03-02T08:15:42Z"}}`,
+			wantAt: "2026-03-02T08:15:42Z"},
+		{name: "no timestamp", eventType: "PushEvent", body: `{"ref":"refs/heads/main"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := time.Now().UTC()
+			raw, err := webhookToRawEvent(tt.eventType, []byte(tt.body))
+			if err != nil {
+				t.Fatalf("webhookToRawEvent: %v", err)
+			}
+			if tt.wantAt == "" {
+				if raw.CreatedAt.Before(before) {
+					t.Errorf("CreatedAt = %s, want now", raw.CreatedAt)
+				}
+				return
+			}
+			if got := raw.CreatedAt.Format(time.RFC3339); got != tt.wantAt {
+				t.Errorf("CreatedAt = %s, want %s", got, tt.wantAt)
+			}
+		})
+	}
+}
+
+// loadRawEvent reads a testdata payload. Webhook deliveries go through
+// webhookToRawEvent, as IngestWebhook does.
+func loadRawEvent(t *testing.T, file, webhook string) *github.RawGitHubEvent {
+	t.Helper()
+
+	dir := "events"
+	if webhook != "" {
+		dir = "webhooks"
+	}
+	body, err := os.ReadFile(filepath.Join("testdata", dir, file+".json"))
+	if err != nil {
+		t.Fatalf("read testdata: %v", err)
+	}
+
+	if webhook != "" {
+		raw, err := webhookToRawEvent(webhookEventTypes[webhook], body)
+		if err != nil {
+			t.Fatalf("webhookToRawEvent: %v", err)
+		}
+		return raw
+	}
+
+	var raw github.RawGitHubEvent
+	if err := json.Unmarshal(body, &raw); err != nil {
+		t.Fatalf("parse testdata: %v", err)
+	}
+	return &raw
+}
+
+// checkNumber compares an optional PR/issue/discussion number (0 = unset)
+func checkNumber(t *testing.T, field string, got *int, want int) {
+	t.Helper()
+	switch {
+	case want == 0 && got != nil:
+		t.Errorf("%s = %d, want nil", field, *got)
+	case want != 0 && got == nil:
+		t.Errorf("%s = nil, want %d", field, want)
+	case want != 0 && *got != want:
+		t.Errorf("%s = %d, want %d", field, *got, want)
+	}
+}
@@ -0,0 +1,153 @@
+package feed
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PRTally is one row of the PR leaderboard
+type PRTally struct {
+	Number       int        `json:"number"`
+	Title        *string    `json:"title,omitempty"` // From the prs table; nil if the PR hasn't been synced yet
+	Author       *string    `json:"author,omitempty"`
+	State        *string    `json:"state,omitempty"` // open, closed, merged
+	Upvotes      int        `json:"upvotes"`
+	Downvotes    int        `json:"downvotes"`
+	Net          int        `json:"net"`
+	UniqueVoters int        `json:"uniqueVoters"`
+	LastVoteAt   *time.Time `json:"lastVoteAt,omitempty"`
+}
+
+// LeaderboardFilters narrows the PR leaderboard
+type LeaderboardFilters struct {
+	State string     // open, closed or merged; empty for all
+	Since *time.Time // Only count votes cast at or after
+	Until *time.Time // Only count votes cast before
+}
+
+// ListPRLeaderboard ranks PRs by net votes (highest first, ties by newest PR),
+// using the same "last vote wins" rule as GetPRVotes. With a time window, each
+// user's last vote inside the window counts. PRs without votes are included
+// with zero tallies. cursor is the nextCursor of the previous page.
+func (s *Store) ListPRLeaderboard(ctx context.Context, filters LeaderboardFilters, limit int, cursor string) ([]*PRTally, error) {
+	var voteWindow string
+	args := []interface{}{}
+	argPos := 1
+
+	if filters.Since != nil {
+		voteWindow += fmt.Sprintf(" AND occurred_at >= $%d", argPos)
+		args = append(args, *filters.Since)
+		argPos++
+	}
+	if filters.Until != nil {
+		voteWindow += fmt.Sprintf(" AND occurred_at < $%d", argPos)
+		args = append(args, *filters.Until)
+		argPos++
+	}
+
+	query := `
+		WITH latest_votes AS (
+			SELECT DISTINCT ON (pr_number, ` + voterIdentity + `)
+				pr_number, choice, occurred_at
+			FROM events
+			WHERE type = 'reaction' AND choice IS NOT NULL AND comment_id IS NULL AND retracted_at IS NULL
+			  AND pr_number IS NOT NULL` + voteWindow + `
+			ORDER BY pr_number, ` + voterIdentity + `, occurred_at DESC
+		),
+		tallies AS (
+			SELECT
+				pr_number,
+				COUNT(*) FILTER (WHERE choice = 1) as upvotes,
+				COUNT(*) FILTER (WHERE choice = -1) as downvotes,
+				COUNT(*) as voters,
+				MAX(occurred_at) as last_vote_at
+			FROM latest_votes
+			GROUP BY pr_number
+		),
+		board AS (
+			SELECT
+				COALESCE(p.number, t.pr_number) as number,
+				p.title, p.author, p.state,
+				COALESCE(t.upvotes, 0) as upvotes,
+				COALESCE(t.downvotes, 0) as downvotes,
+				COALESCE(t.upvotes, 0) - COALESCE(t.downvotes, 0) as net,
+				COALESCE(t.voters, 0) as voters,
+				t.last_vote_at
+			FROM prs p
+			FULL JOIN tallies t ON t.pr_number = p.number
+		)
+		SELECT number, title, author, state, upvotes, downvotes, net, voters, last_vote_at
+		FROM board
+		WHERE 1=1`
+
+	if filters.State != "" {
+		query += fmt.Sprintf(" AND state = $%d", argPos)
+		args = append(args, filters.State)
+		argPos++
+	}
+
+	if cursor != "" {
+		net, number, ok := ParseLeaderboardCursor(cursor)
+		if !ok {
+			return nil, fmt.Errorf("invalid leaderboard cursor: %q", cursor)
+		}
+		query += fmt.Sprintf(" AND (net, number) < ($%d, $%d)", argPos, argPos+1)
+		args = append(args, net, number)
+		argPos += 2
+	}
+
+	query += fmt.Sprintf(" ORDER BY net DESC, number DESC LIMIT $%d", argPos)
+	args = append(args, limit)
+
+	rows, err := s.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get PR leaderboard: %w", err)
+	}
+	defer rows.Close()
+
+	tallies := []*PRTally{}
+	for rows.Next() {
+		t := &PRTally{}
+		err := rows.Scan(
+			&t.Number,
+			&t.Title,
+			&t.Author,
+			&t.State,
+			&t.Upvotes,
+			&t.Downvotes,
+			&t.Net,
+			&t.UniqueVoters,
+			&t.LastVoteAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan PR tally: %w", err)
+		}
+		tallies = append(tallies, t)
+	}
+	return tallies, nil
+}
+
+// LeaderboardCursor returns the cursor that continues the leaderboard after t
+func LeaderboardCursor(t *PRTally) string {
+	return fmt.Sprintf("%d_%d", t.Net, t.Number)
+}
+
+// ParseLeaderboardCursor decodes a cursor produced by LeaderboardCursor
+func ParseLeaderboardCursor(cursor string) (net int, number int, ok bool) {
+	netStr, numberStr, found := strings.Cut(cursor, "_")
+	if !found {
+		return 0, 0, false
+	}
+	net, err := strconv.Atoi(netStr)
+	if err != nil {
+		return 0, 0, false
+	}
+	number, err = strconv.Atoi(numberStr)
+	if err != nil {
+		return 0, 0, false
+	}
+	return net, number, true
+}
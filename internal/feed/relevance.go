@@ -0,0 +1,223 @@
+package feed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// ErrInvalidCursor is returned by List for a relevance cursor that is neither
+// a NextCursor value nor the ID of an event in feed_relevance
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// listByRelevance lists events from the feed_relevance materialized view
+// (last 30 days, scored by reactions, votes and recency; migration 016),
+// highest score first. Pages are keyed on (score, occurred_at, id). Recency
+// is computed at refresh time, so every refresh changes every score: a cursor
+// taken before a refresh can repeat or skip rows after it.
+func (s *Store) listByRelevance(ctx context.Context, filters *ListFilters, limit int, cursor *string) ([]*Event, error) {
+	// Wrap the join so filterClause's unqualified columns stay unambiguous
+	query := fmt.Sprintf(`
+		SELECT %s, relevance_score FROM (
+			SELECT e.*, r.relevance_score
+			FROM events e
+			JOIN feed_relevance r ON r.id = e.id
+		) ranked WHERE 1=1`, eventColumns)
+
+	clause, args := filterClause(filters, 1)
+	query += clause
+	argPos := len(args) + 1
+
+	if cursor != nil && *cursor != "" {
+		score, occurredAt, id, ok := parseRelevanceCursor(*cursor)
+		if !ok {
+			// Plain event ID (e.g. from a client that doesn't use nextCursor)
+			var eventID pgtype.UUID
+			if err := eventID.Scan(*cursor); err != nil {
+				return nil, ErrInvalidCursor
+			}
+			err := s.pool.QueryRow(ctx,
+				`SELECT relevance_score, occurred_at FROM feed_relevance WHERE id = $1`, eventID,
+			).Scan(&score, &occurredAt)
+			if err != nil {
+				if err == pgx.ErrNoRows {
+					return nil, ErrInvalidCursor // Older than the view, or not yet refreshed
+				}
+				return nil, fmt.Errorf("failed to resolve relevance cursor: %w", err)
+			}
+			id = *cursor
+		}
+		query += fmt.Sprintf(
+			" AND (relevance_score, occurred_at, id) < ($%d::double precision, $%d::timestamptz, $%d::uuid)",
+			argPos, argPos+1, argPos+2,
+		)
+		args = append(args, score, occurredAt, id)
+		argPos += 3
+	}
+
+	query += fmt.Sprintf(" ORDER BY relevance_score DESC, occurred_at DESC, id DESC LIMIT $%d", argPos)
+	args = append(args, limit)
+
+	rows, err := s.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events by relevance: %w", err)
+	}
+	defer rows.Close()
+
+	events := []*Event{}
+	for rows.Next() {
+		event := &Event{}
+		var score float64
+		if err := rows.Scan(append(event.scanTargets(), &score)...); err != nil {
+			return nil, fmt.Errorf("failed to scan event: %w", err)
+		}
+		event.RelevanceScore = &score
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// CountByRelevance counts the events matching filters that sort=relevance
+// pages through: those in the feed_relevance view
+func (s *Store) CountByRelevance(ctx context.Context, filters *ListFilters) (int, error) {
+	if err := s.ResolveUser(ctx, filters); err != nil {
+		return 0, err
+	}
+	query := `
+		SELECT COUNT(*) FROM (
+			SELECT e.*
+			FROM events e
+			JOIN feed_relevance r ON r.id = e.id
+		) ranked WHERE 1=1`
+
+	clause, args := filterClause(filters, 1)
+	query += clause
+
+	var count int
+	if err := s.pool.QueryRow(ctx, query, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count events by relevance: %w", err)
+	}
+	return count, nil
+}
+
+// NextCursor returns the pagination cursor that continues a listing after event.
+// Chronological sorts use the event ID; relevance encodes the sort key itself.
+func NextCursor(sort string, event *Event) string {
+	if sort == "relevance" && event.RelevanceScore != nil {
+		return fmt.Sprintf("%s_%d_%s",
+			strconv.FormatFloat(*event.RelevanceScore, 'g', -1, 64),
+			event.OccurredAt.UnixMicro(),
+			event.ID,
+		)
+	}
+	return event.ID
+}
+
+// parseRelevanceCursor decodes a cursor produced by NextCursor for sort=relevance
+func parseRelevanceCursor(cursor string) (score float64, occurredAt time.Time, id string, ok bool) {
+	parts := strings.SplitN(cursor, "_", 3)
+	if len(parts) != 3 {
+		return 0, time.Time{}, "", false
+	}
+	score, err := strconv.ParseFloat(parts[0], 64)
+	if err != nil {
+		return 0, time.Time{}, "", false
+	}
+	micros, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return 0, time.Time{}, "", false
+	}
+	var eventID pgtype.UUID
+	if eventID.Scan(parts[2]) != nil {
+		return 0, time.Time{}, "", false
+	}
+	return score, time.UnixMicro(micros), parts[2], true
+}
+
+// RefreshRelevance recomputes the feed_relevance view without blocking readers
+func (s *Store) RefreshRelevance(ctx context.Context) error {
+	if _, err := s.pool.Exec(ctx, `REFRESH MATERIALIZED VIEW CONCURRENTLY feed_relevance`); err != nil {
+		return fmt.Errorf("failed to refresh feed_relevance: %w", err)
+	}
+	return nil
+}
+
+// RelevanceRefresher periodically refreshes the feed_relevance view so
+// sort=relevance reflects new events, reactions and recency
+type RelevanceRefresher struct {
+	store    *Store
+	interval time.Duration
+
+	// Lifecycle
+	stopCh   chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// NewRelevanceRefresher creates a refresher that runs every interval
+func NewRelevanceRefresher(store *Store, interval time.Duration) *RelevanceRefresher {
+	return &RelevanceRefresher{
+		store:    store,
+		interval: interval,
+		stopCh:   make(chan struct{}),
+	}
+}
+
+// Run refreshes once immediately, then on every tick until stopped
+func (r *RelevanceRefresher) Run(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	// Cancel an in-flight refresh on Stop
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		<-r.stopCh
+		cancel()
+	}()
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+
+		r.refresh(ctx)
+		for {
+			select {
+			case <-ticker.C:
+				r.refresh(ctx)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// Stop halts the refresh loop. Safe to call multiple times.
+func (r *RelevanceRefresher) Stop() {
+	r.stopOnce.Do(func() {
+		close(r.stopCh)
+		r.wg.Wait()
+	})
+}
+
+// refresh runs one refresh, logging failures
+func (r *RelevanceRefresher) refresh(ctx context.Context) {
+	start := time.Now()
+	if err := r.store.RefreshRelevance(ctx); err != nil {
+		if ctx.Err() == nil {
+			slog.Error("Relevance refresh failed", "error", err)
+		}
+		return
+	}
+	slog.Debug("Relevance view refreshed", "duration", time.Since(start).Round(time.Millisecond))
+}
@@ -0,0 +1,330 @@
+package feed
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/skridlevsky/openchaos-feed/internal/github"
+)
+
+// Builders for feed events from REST API objects. Payloads mirror the Events
+// API shape ({action, number, pull_request} / {action, issue} / {action,
+// issue, comment}) so the frontend renders REST-sourced and polled events alike.
+
+// PROpenedEvent builds a pr_opened event from a REST pull request.
+// Keyed by the PR's GitHub ID, same as PullRequestEvent "opened".
+func PROpenedEvent(pr *github.GitHubPR) *Event {
+	payload, _ := json.Marshal(map[string]interface{}{
+		"action":       "opened",
+		"number":       pr.Number,
+		"pull_request": pr,
+	})
+
+	prNumber := pr.Number
+	githubID := pr.ID
+	occurredAt, _ := time.Parse(time.RFC3339, pr.CreatedAt)
+	return &Event{
+		Type:         EventPROpened,
+		GitHubUser:   pr.User.Login,
+		GitHubUserID: pr.User.ID,
+		PRNumber:     &prNumber,
+		GitHubID:     &githubID,
+		Payload:      payload,
+		ContentHash:  computeContentHash(payload),
+		OccurredAt:   occurredAt,
+	}
+}
+
+// PRStateEvent builds a single event for a REST pull request from its current
+// state: pr_merged or pr_closed for closed PRs, pr_opened otherwise. Used by
+// full syncs, which see each PR once rather than its individual transitions.
+// Timestamped at creation and keyed by the PR's GitHub ID.
+func PRStateEvent(pr *github.GitHubPR) *Event {
+	event := PROpenedEvent(pr)
+	if pr.State != "closed" {
+		return event
+	}
+
+	// The pulls list omits "merged"; merged_at is the reliable signal
+	event.Type = EventPRClosed
+	if pr.Merged || pr.MergedAt != nil {
+		event.Type = EventPRMerged
+	}
+	event.Payload, _ = json.Marshal(map[string]interface{}{
+		"action":       "closed",
+		"number":       pr.Number,
+		"pull_request": pr,
+	})
+	event.ContentHash = computeContentHash(event.Payload)
+	return event
+}
+
+// IssueStateEvent builds a single event for a REST issue from its current
+// state: issue_closed or issue_opened. Counterpart to PRStateEvent.
+func IssueStateEvent(issue *github.GitHubIssue) *Event {
+	event := IssueOpenedEvent(issue)
+	if issue.State != "closed" {
+		return event
+	}
+
+	event.Type = EventIssueClosed
+	event.Payload, _ = json.Marshal(map[string]interface{}{
+		"action": "closed",
+		"issue":  issue,
+	})
+	event.ContentHash = computeContentHash(event.Payload)
+	return event
+}
+
+// StarEvent builds a star event from a stargazer.
+// Keyed by the user's ID: a user can star a repository once.
+func StarEvent(stargazer *github.Stargazer) *Event {
+	payload, _ := json.Marshal(stargazer)
+
+	githubID := stargazer.User.ID
+	return &Event{
+		Type:         EventStar,
+		GitHubUser:   stargazer.User.Login,
+		GitHubUserID: stargazer.User.ID,
+		GitHubID:     &githubID,
+		Payload:      payload,
+		ContentHash:  computeContentHash(payload),
+		OccurredAt:   stargazer.StarredAt,
+	}
+}
+
+// ForkEvent builds a fork event from a REST fork, keyed by the fork's repository ID
+func ForkEvent(fork *github.Fork) *Event {
+	payload, _ := json.Marshal(fork)
+
+	githubID := fork.ID
+	return &Event{
+		Type:         EventFork,
+		GitHubUser:   fork.Owner.Login,
+		GitHubUserID: fork.Owner.ID,
+		GitHubID:     &githubID,
+		Payload:      payload,
+		ContentHash:  computeContentHash(payload),
+		OccurredAt:   fork.CreatedAt,
+	}
+}
+
+// IssueOpenedEvent builds an issue_opened event from a REST issue.
+// Keyed by the issue's GitHub ID, same as IssuesEvent "opened".
+func IssueOpenedEvent(issue *github.GitHubIssue) *Event {
+	payload, _ := json.Marshal(map[string]interface{}{
+		"action": "opened",
+		"issue":  issue,
+	})
+
+	issueNumber := issue.Number
+	githubID := issue.ID
+	return &Event{
+		Type:         EventIssueOpened,
+		GitHubUser:   issue.User.Login,
+		GitHubUserID: issue.User.ID,
+		IssueNumber:  &issueNumber,
+		GitHubID:     &githubID,
+		Payload:      payload,
+		ContentHash:  computeContentHash(payload),
+		OccurredAt:   issue.CreatedAt,
+	}
+}
+
+// CommentParent describes the issue or PR a REST comment belongs to
+type CommentParent struct {
+	Title string
+	IsPR  bool
+}
+
+// IssueCommentCreatedEvent builds an issue_comment event from a REST comment.
+// parent may be nil if the comment's issue/PR is unknown; the event then has
+// neither pr_number nor issue_number set.
+// Keyed by the comment ID, same as IssueCommentEvent "created".
+func IssueCommentCreatedEvent(comment *github.GitHubComment, parent *CommentParent) *Event {
+	parentNumber := issueNumberFromURL(comment.IssueURL)
+	issue := map[string]interface{}{
+		"number": parentNumber,
+	}
+	if parent != nil {
+		issue["title"] = parent.Title
+		if parent.IsPR {
+			issue["pull_request"] = map[string]interface{}{}
+		}
+	}
+
+	payload, _ := json.Marshal(map[string]interface{}{
+		"action": "created",
+		"issue":  issue,
+		"comment": map[string]interface{}{
+			"id":         comment.ID,
+			"body":       comment.Body,
+			"user":       comment.User,
+			"created_at": comment.CreatedAt,
+			"updated_at": comment.UpdatedAt,
+		},
+	})
+
+	commentID := comment.ID
+	githubID := comment.ID
+	event := &Event{
+		Type:         EventIssueComment,
+		GitHubUser:   comment.User.Login,
+		GitHubUserID: comment.User.ID,
+		CommentID:    &commentID,
+		GitHubID:     &githubID,
+		Payload:      payload,
+		ContentHash:  computeContentHash(payload),
+		OccurredAt:   comment.CreatedAt,
+	}
+	if parent != nil && parentNumber > 0 {
+		if parent.IsPR {
+			event.PRNumber = &parentNumber
+		} else {
+			event.IssueNumber = &parentNumber
+		}
+	}
+	return event
+}
+
+// IssueLifecycleEvent builds a close/merge/reopen event from a repository
+// issue event. Returns nil for issue event kinds the feed doesn't track.
+// GitHub records both "merged" and "closed" for a merge; pass skipClose for
+// PRs whose merge is already represented so only pr_merged is stored.
+func IssueLifecycleEvent(ie *github.IssueEvent, skipClose bool) *Event {
+	isPR := ie.Issue.PullRequest != nil
+
+	var eventType EventType
+	action := ie.Event
+	switch {
+	case isPR && ie.Event == "merged":
+		eventType = EventPRMerged
+		action = "closed" // Events API reports merges as closed + merged
+	case isPR && ie.Event == "closed":
+		if skipClose {
+			return nil
+		}
+		eventType = EventPRClosed
+	case isPR && ie.Event == "reopened":
+		eventType = EventPRReopened
+	case !isPR && ie.Event == "closed":
+		eventType = EventIssueClosed
+	case !isPR && ie.Event == "reopened":
+		eventType = EventIssueReopened
+	default:
+		return nil
+	}
+
+	number := ie.Issue.Number
+	event := &Event{
+		Type:         eventType,
+		GitHubUser:   ie.Actor.Login,
+		GitHubUserID: ie.Actor.ID,
+		OccurredAt:   ie.CreatedAt,
+	}
+
+	var payload []byte
+	if isPR {
+		pr := map[string]interface{}{
+			"id":       ie.Issue.ID,
+			"number":   ie.Issue.Number,
+			"title":    ie.Issue.Title,
+			"state":    ie.Issue.State,
+			"html_url": ie.Issue.HTMLURL,
+			"user":     ie.Issue.User,
+			"merged":   eventType == EventPRMerged,
+		}
+		payload, _ = json.Marshal(map[string]interface{}{
+			"action":       action,
+			"number":       number,
+			"pull_request": pr,
+		})
+		event.PRNumber = &number
+		event.SourceKey = lifecycleSourceKey("pr", number, eventType, ie.CreatedAt)
+	} else {
+		payload, _ = json.Marshal(map[string]interface{}{
+			"action": action,
+			"issue":  ie.Issue,
+		})
+		event.IssueNumber = &number
+		event.SourceKey = lifecycleSourceKey("issue", number, eventType, ie.CreatedAt)
+	}
+
+	event.Payload = payload
+	event.ContentHash = computeContentHash(payload)
+	return event
+}
+
+// ReactionEvent builds a reaction event from a REST reaction on a PR, issue or
+// comment. +1/-1 reactions carry a choice, matching the backfill.
+func ReactionEvent(reaction github.DetailedReaction, target ReactionTarget) *Event {
+	var choice *int8
+	if reaction.Content == "+1" {
+		c := int8(1)
+		choice = &c
+	} else if reaction.Content == "-1" {
+		c := int8(-1)
+		choice = &c
+	}
+
+	_, targetValue, targetKey := target.scope(1)
+	reactionPayload, _ := json.Marshal(map[string]interface{}{
+		"id":         reaction.ID,
+		"content":    reaction.Content,
+		"user":       reaction.User,
+		"created_at": reaction.CreatedAt,
+		targetKey:    targetValue,
+	})
+
+	githubID := reaction.ID
+	reactionType := reaction.Content
+	event := &Event{
+		Type:         EventReaction,
+		GitHubUser:   reaction.User.Login,
+		GitHubUserID: reaction.User.ID,
+		Choice:       choice,
+		ReactionType: &reactionType,
+		GitHubID:     &githubID,
+		Payload:      reactionPayload,
+		ContentHash:  computeContentHash(reactionPayload),
+		OccurredAt:   reaction.CreatedAt,
+	}
+	target.apply(event)
+	return event
+}
+
+// lifecycleSourceKey identifies a PR/issue state change independent of which
+// API reported it, e.g. "pr:42:pr_merged:1717171717"
+func lifecycleSourceKey(kind string, number int, eventType EventType, at time.Time) *string {
+	key := fmt.Sprintf("%s:%d:%s:%d", kind, number, eventType, at.Unix())
+	return &key
+}
+
+// actionSourceKey is lifecycleSourceKey for actions that can repeat within
+// the same second on different subjects (e.g. several labels applied at once).
+// With no subject it is identical to lifecycleSourceKey, so catch-up and
+// Events API copies of a close or reopen dedupe against each other.
+func actionSourceKey(kind string, number int, eventType EventType, subject string, at time.Time) *string {
+	if subject == "" {
+		return lifecycleSourceKey(kind, number, eventType, at)
+	}
+	key := fmt.Sprintf("%s:%d:%s:%s:%d", kind, number, eventType, subject, at.Unix())
+	return &key
+}
+
+// issueNumberFromURL extracts the trailing number from an issue API URL
+// (e.g. https://api.github.com/repos/o/r/issues/42 → 42). Returns 0 if absent.
+func issueNumberFromURL(url string) int {
+	idx := strings.LastIndex(url, "/")
+	if idx < 0 {
+		return 0
+	}
+	n, err := strconv.Atoi(url[idx+1:])
+	if err != nil {
+		return 0
+	}
+	return n
+}
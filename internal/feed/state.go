@@ -0,0 +1,122 @@
+package feed
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sort"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Ingester state keys (ingester_state.key)
+const (
+	stateEventsETag           = "events.etag"
+	stateOpenPRs              = "events.open_prs"
+	stateReactionsCycle       = "reactions.cycle"
+	stateDiscussionsCycle     = "discussions.cycle"
+	stateDiscussionsWatermark = "discussions.updated_at"
+)
+
+// LoadState decodes the stored value for key into target.
+// Returns false if no value has been saved yet.
+func (s *Store) LoadState(ctx context.Context, key string, target interface{}) (bool, error) {
+	var raw []byte
+	err := s.pool.QueryRow(ctx, `SELECT value FROM ingester_state WHERE key = $1`, key).Scan(&raw)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to load ingester state %s: %w", key, err)
+	}
+
+	if err := json.Unmarshal(raw, target); err != nil {
+		return false, fmt.Errorf("failed to decode ingester state %s: %w", key, err)
+	}
+	return true, nil
+}
+
+// SaveState stores value (JSON-encoded) under key, replacing any previous value
+func (s *Store) SaveState(ctx context.Context, key string, value interface{}) error {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to encode ingester state %s: %w", key, err)
+	}
+
+	_, err = s.pool.Exec(ctx, `
+		INSERT INTO ingester_state (key, value, updated_at)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT (key) DO UPDATE SET value = EXCLUDED.value, updated_at = EXCLUDED.updated_at
+	`, key, raw)
+	if err != nil {
+		return fmt.Errorf("failed to save ingester state %s: %w", key, err)
+	}
+	return nil
+}
+
+// loadState restores poller cursors saved by a previous process, so a restart
+// keeps its ETag (304s are free), open PR set and full-scan cadence.
+// Missing or unreadable state falls back to a cold start for that value.
+func (ing *Ingester) loadState(ctx context.Context) {
+	var etag string
+	var openPRs []int
+	var reactionsCycle, discussionsCycle int
+	var lastSeen eventsCursor
+
+	loaded := map[string]interface{}{
+		stateEventsETag:       &etag,
+		stateEventsLastSeen:   &lastSeen,
+		stateOpenPRs:          &openPRs,
+		stateReactionsCycle:   &reactionsCycle,
+		stateDiscussionsCycle: &discussionsCycle,
+	}
+	for key, target := range loaded {
+		if _, err := ing.store.LoadState(ctx, key, target); err != nil {
+			slog.Warn("Failed to load ingester state", "key", key, "error", err)
+		}
+	}
+
+	ing.mu.Lock()
+	ing.lastEventETag = etag
+	ing.lastSeenEvent = lastSeen
+	for _, number := range openPRs {
+		ing.openPRs[number] = true
+	}
+	ing.reactionsCycle = reactionsCycle
+	ing.discussionsCycle = discussionsCycle
+	ing.mu.Unlock()
+
+	slog.Info("Ingester state restored",
+		"etag_cached", etag != "",
+		"open_prs", len(openPRs),
+		"reactions_cycle", reactionsCycle,
+		"discussions_cycle", discussionsCycle,
+	)
+}
+
+// saveState persists one poller value. Failures are logged, not fatal:
+// the in-memory value stays authoritative until the next save.
+func (ing *Ingester) saveState(ctx context.Context, key string, value interface{}) {
+	if err := ing.store.SaveState(ctx, key, value); err != nil {
+		slog.Warn("Failed to save ingester state", "key", key, "error", err)
+	}
+}
+
+// saveOpenPRs persists the open PR set if it changed since the last save
+func (ing *Ingester) saveOpenPRs(ctx context.Context) {
+	ing.mu.Lock()
+	if !ing.openPRsDirty {
+		ing.mu.Unlock()
+		return
+	}
+	numbers := make([]int, 0, len(ing.openPRs))
+	for number := range ing.openPRs {
+		numbers = append(numbers, number)
+	}
+	ing.openPRsDirty = false
+	ing.mu.Unlock()
+
+	sort.Ints(numbers)
+	ing.saveState(ctx, stateOpenPRs, numbers)
+}
@@ -12,36 +12,49 @@ import (
 
 // Store provides database operations for events
 type Store struct {
-	pool *pgxpool.Pool
+	pool           *pgxpool.Pool
+	deletionPolicy DeletionPolicy
 }
 
-// NewStore creates a new event store
+// NewStore creates a new event store. Deleted comments are retained by default.
 func NewStore(pool *pgxpool.Pool) *Store {
-	return &Store{pool: pool}
+	return &Store{pool: pool, deletionPolicy: DeletionRetain}
+}
+
+// querier is satisfied by both *pgxpool.Pool and pgx.Tx so inserts can run
+// standalone or as part of a larger transaction.
+type querier interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
 }
 
 // Insert inserts a new event into the database.
-// Deduplication: ON CONFLICT (github_id) catches exact ID matches.
+// Deduplication: ON CONFLICT catches exact github_id or source_key matches.
 // The WHERE NOT EXISTS clause catches content duplicates that differ
 // only in github_id (e.g. legacy NULL-github_id rows vs new rows).
+// New rows are announced on the feed_events channel by trigger (migration 012).
 func (s *Store) Insert(ctx context.Context, event *Event) error {
+	return insertEvent(ctx, s.pool, event)
+}
+
+// insertEvent is the shared implementation for Insert and transactional inserts
+func insertEvent(ctx context.Context, q querier, event *Event) error {
 	query := `
 		WITH new_event (
 			type, github_user, github_user_id,
 			pr_number, issue_number, discussion_number, comment_id,
 			choice, reaction_type, github_id, payload, content_hash,
-			occurred_at
+			related_event_id, source_key, occurred_at
 		) AS (
 			VALUES ($1::varchar, $2::varchar, $3::bigint,
 				$4::int, $5::int, $6::int, $7::bigint,
 				$8::smallint, $9::varchar, $10::bigint, $11::jsonb, $12::varchar,
-				$13::timestamptz)
+				$13::uuid, $14::varchar, $15::timestamptz)
 		)
 		INSERT INTO events (
 			type, github_user, github_user_id,
 			pr_number, issue_number, discussion_number, comment_id,
 			choice, reaction_type, github_id, payload, content_hash,
-			occurred_at
+			related_event_id, source_key, occurred_at
 		)
 		SELECT * FROM new_event n
 		WHERE NOT EXISTS (
@@ -58,16 +71,16 @@ func (s *Store) Insert(ctx context.Context, event *Event) error {
 			  AND e.github_user = n.github_user
 			  AND n.type IN ('star', 'fork')
 		)
-		ON CONFLICT (github_id) DO NOTHING
+		ON CONFLICT DO NOTHING
 		RETURNING id, ingested_at
 	`
 
-	err := s.pool.QueryRow(
+	err := q.QueryRow(
 		ctx, query,
 		event.Type, event.GitHubUser, event.GitHubUserID,
 		event.PRNumber, event.IssueNumber, event.DiscussionNumber, event.CommentID,
 		event.Choice, event.ReactionType, event.GitHubID, event.Payload, event.ContentHash,
-		event.OccurredAt,
+		event.RelatedEventID, event.SourceKey, event.OccurredAt,
 	).Scan(&event.ID, &event.IngestedAt)
 
 	if err != nil {
@@ -84,19 +97,24 @@ func (s *Store) Insert(ctx context.Context, event *Event) error {
 // eventColumns is the standard column list for event queries
 const eventColumns = `id, type, github_user, github_user_id,
 			pr_number, issue_number, discussion_number, comment_id,
-			choice, reaction_type, github_id, payload, content_hash,
-			edit_history, occurred_at, ingested_at`
+			choice, reaction_type, github_id, source_key, payload, content_hash,
+			edit_history, related_event_id, retracted_at, deleted_at, occurred_at, ingested_at`
 
 // scanEvent scans a row into an Event struct
 func scanEvent(row pgx.Row) (*Event, error) {
 	event := &Event{}
-	err := row.Scan(
+	err := row.Scan(event.scanTargets()...)
+	return event, err
+}
+
+// scanTargets returns pointers to the fields of eventColumns, in order
+func (event *Event) scanTargets() []interface{} {
+	return []interface{}{
 		&event.ID, &event.Type, &event.GitHubUser, &event.GitHubUserID,
 		&event.PRNumber, &event.IssueNumber, &event.DiscussionNumber, &event.CommentID,
-		&event.Choice, &event.ReactionType, &event.GitHubID, &event.Payload, &event.ContentHash,
-		&event.EditHistory, &event.OccurredAt, &event.IngestedAt,
-	)
-	return event, err
+		&event.Choice, &event.ReactionType, &event.GitHubID, &event.SourceKey, &event.Payload, &event.ContentHash,
+		&event.EditHistory, &event.RelatedEventID, &event.RetractedAt, &event.DeletedAt, &event.OccurredAt, &event.IngestedAt,
+	}
 }
 
 // scanEvents scans multiple rows into Event structs
@@ -104,12 +122,7 @@ func scanEvents(rows pgx.Rows) ([]*Event, error) {
 	events := []*Event{}
 	for rows.Next() {
 		event := &Event{}
-		err := rows.Scan(
-			&event.ID, &event.Type, &event.GitHubUser, &event.GitHubUserID,
-			&event.PRNumber, &event.IssueNumber, &event.DiscussionNumber, &event.CommentID,
-			&event.Choice, &event.ReactionType, &event.GitHubID, &event.Payload, &event.ContentHash,
-			&event.EditHistory, &event.OccurredAt, &event.IngestedAt,
-		)
+		err := rows.Scan(event.scanTargets()...)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan event: %w", err)
 		}
@@ -127,10 +140,10 @@ func (s *Store) UpdateCommentEdit(ctx context.Context, commentID int64, newPaylo
 		SET payload = $2,
 			content_hash = $3,
 			edit_history = $4::jsonb || edit_history
-		WHERE comment_id = $1
+		WHERE comment_id = $1 AND type = ANY($5)
 	`
 
-	tag, err := s.pool.Exec(ctx, query, commentID, newPayload, computeContentHash(newPayload), editEntry)
+	tag, err := s.pool.Exec(ctx, query, commentID, newPayload, computeContentHash(newPayload), editEntry, commentRowTypes)
 	if err != nil {
 		return fmt.Errorf("failed to update comment edit: %w", err)
 	}
@@ -140,13 +153,96 @@ func (s *Store) UpdateCommentEdit(ctx context.Context, commentID int64, newPaylo
 	return nil
 }
 
+// RecordPREdit folds a pr_edited action into the PR's canonical event (see
+// recordParentEdit): newTitle/newBody, when non-nil, replace the current text
+// and previous is prepended to its edit_history.
+func (s *Store) RecordPREdit(ctx context.Context, prNumber int, newTitle, newBody *string, previous EditHistoryEntry) error {
+	return s.recordParentEdit(ctx, "pull_request", "pr_number", prNumber, []EventType{
+		EventPROpened, EventPRClosed, EventPRMerged, EventPRReopened,
+	}, newTitle, newBody, previous)
+}
+
+// RecordIssueEdit is RecordPREdit for issues
+func (s *Store) RecordIssueEdit(ctx context.Context, issueNumber int, newTitle, newBody *string, previous EditHistoryEntry) error {
+	return s.recordParentEdit(ctx, "issue", "issue_number", issueNumber, []EventType{
+		EventIssueOpened, EventIssueClosed, EventIssueReopened,
+	}, newTitle, newBody, previous)
+}
+
+// recordParentEdit updates the canonical event of a PR or issue: its opened
+// event, or the earliest lifecycle event when history starts later (backfill
+// stores one state event per PR/issue). payloadKey names the payload object
+// holding title and body; numberColumn is trusted, never user input.
+func (s *Store) recordParentEdit(ctx context.Context, payloadKey, numberColumn string, number int, types []EventType, newTitle, newBody *string, previous EditHistoryEntry) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin edit: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var id string
+	var payload []byte
+	err = tx.QueryRow(ctx, `
+		SELECT id, payload FROM events
+		WHERE `+numberColumn+` = $1 AND type = ANY($2)
+		ORDER BY (type = $3) DESC, occurred_at ASC
+		LIMIT 1
+		FOR UPDATE
+	`, number, types, types[0]).Scan(&id, &payload)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return fmt.Errorf("no %s event for #%d", payloadKey, number)
+		}
+		return fmt.Errorf("failed to find edited %s: %w", payloadKey, err)
+	}
+
+	// Patch title/body via RawMessage so other fields (e.g. 64-bit IDs) round-trip untouched
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(payload, &fields); err != nil {
+		return fmt.Errorf("failed to parse %s payload: %w", payloadKey, err)
+	}
+	var object map[string]json.RawMessage
+	if raw, ok := fields[payloadKey]; ok {
+		json.Unmarshal(raw, &object)
+	}
+	if object == nil {
+		object = make(map[string]json.RawMessage)
+	}
+	if newTitle != nil {
+		object["title"], _ = json.Marshal(*newTitle)
+	}
+	if newBody != nil {
+		object["body"], _ = json.Marshal(*newBody)
+	}
+	fields[payloadKey], _ = json.Marshal(object)
+	newPayload, _ := json.Marshal(fields)
+
+	editEntry, _ := json.Marshal([]EditHistoryEntry{previous})
+	_, err = tx.Exec(ctx, `
+		UPDATE events
+		SET payload = $2,
+			content_hash = $3,
+			edit_history = $4::jsonb || edit_history
+		WHERE id = $1
+		  AND NOT edit_history @> $4::jsonb -- Same edit seen via webhook and polling
+	`, id, newPayload, computeContentHash(newPayload), editEntry)
+	if err != nil {
+		return fmt.Errorf("failed to record %s edit: %w", payloadKey, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit %s edit: %w", payloadKey, err)
+	}
+	return nil
+}
+
 // DeduplicateStarsForks removes duplicate star/fork events, keeping the earliest per user.
 func (s *Store) DeduplicateStarsForks(ctx context.Context) (int64, error) {
 	query := `
 		DELETE FROM events
 		WHERE id IN (
 			SELECT id FROM (
-				SELECT id, ROW_NUMBER() OVER (PARTITION BY type, github_user ORDER BY occurred_at ASC) as rn
+				SELECT id, ROW_NUMBER() OVER (PARTITION BY type, ` + voterIdentity + ` ORDER BY occurred_at ASC) as rn
 				FROM events
 				WHERE type IN ('star', 'fork')
 			) sub
@@ -160,41 +256,284 @@ func (s *Store) DeduplicateStarsForks(ctx context.Context) (int64, error) {
 	return tag.RowsAffected(), nil
 }
 
-// DeleteByType removes all events of a given type. Returns the number of rows deleted.
-func (s *Store) DeleteByType(ctx context.Context, eventType EventType) (int64, error) {
-	tag, err := s.pool.Exec(ctx, `DELETE FROM events WHERE type = $1`, eventType)
+// ReplaceFlatPayload rewrites a stored event still in the old flat payload
+// format (no "action" key) with event's type and payload, matched by github_id
+// among types. The row keeps its ID, edit history and tombstone. Reports
+// whether a row was rewritten.
+func (s *Store) ReplaceFlatPayload(ctx context.Context, event *Event, types []EventType) (bool, error) {
+	if event.GitHubID == nil {
+		return false, nil
+	}
+	tag, err := s.pool.Exec(ctx, `
+		UPDATE events
+		SET type = $2, payload = $3, content_hash = $4
+		WHERE github_id = $1 AND type = ANY($5) AND NOT payload ? 'action'
+	`, *event.GitHubID, event.Type, event.Payload, event.ContentHash, types)
+	if err != nil {
+		return false, fmt.Errorf("failed to replace flat payload: %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// DeleteUnkeyedFlatEvents removes old flat-payload events of the given types
+// that have no github_id, so ReplaceFlatPayload can't match them and a sync
+// would store duplicates. Tombstoned rows are kept. Returns the number of
+// rows deleted.
+func (s *Store) DeleteUnkeyedFlatEvents(ctx context.Context, types []EventType) (int64, error) {
+	tag, err := s.pool.Exec(ctx, `
+		DELETE FROM events
+		WHERE type = ANY($1) AND github_id IS NULL AND NOT payload ? 'action' AND deleted_at IS NULL
+	`, types)
 	if err != nil {
-		return 0, fmt.Errorf("failed to delete events by type: %w", err)
+		return 0, fmt.Errorf("failed to delete unkeyed flat events: %w", err)
 	}
 	return tag.RowsAffected(), nil
 }
 
-// DeleteByTypes removes all events matching any of the given types. Returns total rows deleted.
-func (s *Store) DeleteByTypes(ctx context.Context, types []EventType) (int64, error) {
-	typeStrs := make([]string, len(types))
-	for i, t := range types {
-		typeStrs[i] = string(t)
+// DeletionPolicy controls what happens to a deleted comment's content
+type DeletionPolicy string
+
+const (
+	DeletionRetain DeletionPolicy = "retain" // Keep body and edit history as public record
+	DeletionRedact DeletionPolicy = "redact" // Blank body and edit history, keep metadata
+)
+
+// SetDeletionPolicy sets how TombstoneComment treats deleted comment content
+func (s *Store) SetDeletionPolicy(policy DeletionPolicy) {
+	s.deletionPolicy = policy
+}
+
+// commentRowTypes are the event types whose row is the comment itself.
+// Reactions on a comment carry its comment_id too, so updates keyed on
+// comment_id must be limited to these.
+var commentRowTypes = []EventType{EventIssueComment, EventReviewComment}
+
+// TombstoneComment records a comment deleted on GitHub. The original row is
+// kept and stamped with deleted_at (its body and edit history are blanked
+// under DeletionRedact), and a comment_deleted event linking back to it is
+// recorded. Idempotent: returns nil without error if the comment is unknown
+// or already tombstoned.
+func (s *Store) TombstoneComment(ctx context.Context, commentID int64, deletedBy string, deletedByID int64, deletedAt time.Time) (*Event, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin comment tombstone: %w", err)
 	}
-	tag, err := s.pool.Exec(ctx, `DELETE FROM events WHERE type = ANY($1)`, typeStrs)
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, `
+		SELECT id, type, github_user, github_user_id, pr_number, issue_number, payload
+		FROM events
+		WHERE comment_id = $1 AND type = ANY($2) AND deleted_at IS NULL
+		ORDER BY occurred_at ASC
+		FOR UPDATE
+	`, commentID, commentRowTypes)
 	if err != nil {
-		return 0, fmt.Errorf("failed to delete events by types: %w", err)
+		return nil, fmt.Errorf("failed to find deleted comment: %w", err)
 	}
-	return tag.RowsAffected(), nil
+
+	var ids []string
+	var original *Event
+	for rows.Next() {
+		e := &Event{}
+		if err := rows.Scan(&e.ID, &e.Type, &e.GitHubUser, &e.GitHubUserID, &e.PRNumber, &e.IssueNumber, &e.Payload); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan deleted comment: %w", err)
+		}
+		if original == nil {
+			original = e
+		}
+		ids = append(ids, e.ID)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read deleted comment: %w", err)
+	}
+	if original == nil {
+		return nil, nil
+	}
+
+	// Carry the parent issue/PR (number and title) so the tombstone reads on its own
+	var parent struct {
+		Issue       json.RawMessage `json:"issue"`
+		PullRequest *struct {
+			Number int    `json:"number"`
+			Title  string `json:"title"`
+		} `json:"pull_request"`
+	}
+	json.Unmarshal(original.Payload, &parent)
+
+	tombstone := map[string]interface{}{
+		"action": "deleted",
+		"comment": map[string]interface{}{
+			"id":   commentID,
+			"type": original.Type,
+			"user": map[string]interface{}{"login": original.GitHubUser, "id": original.GitHubUserID},
+		},
+		"deleted_by": map[string]interface{}{"login": deletedBy, "id": deletedByID},
+		"policy":     s.deletionPolicy,
+	}
+	if parent.Issue != nil {
+		tombstone["issue"] = parent.Issue
+	}
+	if parent.PullRequest != nil {
+		tombstone["pull_request"] = parent.PullRequest
+	}
+	payload, _ := json.Marshal(tombstone)
+
+	originalID := original.ID
+	sourceKey := fmt.Sprintf("comment:%d:deleted", commentID)
+	event := &Event{
+		Type:           EventCommentDeleted,
+		GitHubUser:     deletedBy,
+		GitHubUserID:   deletedByID,
+		PRNumber:       original.PRNumber,
+		IssueNumber:    original.IssueNumber,
+		CommentID:      &commentID,
+		SourceKey:      &sourceKey,
+		RelatedEventID: &originalID,
+		Payload:        payload,
+		ContentHash:    computeContentHash(payload),
+		OccurredAt:     deletedAt,
+	}
+	if err := insertEvent(ctx, tx, event); err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE events SET
+			deleted_at = $2,
+			payload = CASE
+				WHEN $3::boolean AND payload ? 'comment'
+				THEN jsonb_set(payload, '{comment}', (payload->'comment') || '{"body": "", "body_redacted": true}')
+				ELSE payload
+			END,
+			edit_history = CASE
+				WHEN $3::boolean
+				THEN COALESCE((SELECT jsonb_agg(h || '{"body": ""}') FROM jsonb_array_elements(edit_history) h), '[]')
+				ELSE edit_history
+			END
+		WHERE id = ANY($1)
+	`, ids, deletedAt, s.deletionPolicy == DeletionRedact)
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark comment deleted: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit comment tombstone: %w", err)
+	}
+
+	return event, nil
 }
 
-// DeleteByCommentID removes a comment event when it gets deleted on GitHub
-func (s *Store) DeleteByCommentID(ctx context.Context, commentID int64) error {
-	query := `DELETE FROM events WHERE comment_id = $1`
-	tag, err := s.pool.Exec(ctx, query, commentID)
+// RecordWebhookDelivery marks a webhook delivery as seen.
+// Returns false if the delivery ID was already recorded (a retry or redelivery).
+func (s *Store) RecordWebhookDelivery(ctx context.Context, deliveryID, event string) (bool, error) {
+	tag, err := s.pool.Exec(ctx, `
+		INSERT INTO webhook_deliveries (delivery_id, event)
+		VALUES ($1, $2)
+		ON CONFLICT (delivery_id) DO NOTHING
+	`, deliveryID, event)
 	if err != nil {
-		return fmt.Errorf("failed to delete comment: %w", err)
+		return false, fmt.Errorf("failed to record webhook delivery: %w", err)
 	}
-	if tag.RowsAffected() == 0 {
-		return fmt.Errorf("comment %d not found", commentID)
+	return tag.RowsAffected() == 1, nil
+}
+
+// ForgetWebhookDelivery removes a delivery record so a failed delivery can be
+// retried by GitHub's redelivery.
+func (s *Store) ForgetWebhookDelivery(ctx context.Context, deliveryID string) error {
+	if _, err := s.pool.Exec(ctx, `DELETE FROM webhook_deliveries WHERE delivery_id = $1`, deliveryID); err != nil {
+		return fmt.Errorf("failed to forget webhook delivery: %w", err)
 	}
 	return nil
 }
 
+// InsertDiscussionEvent stores a GraphQL discussion event. With claim set, it
+// first claims a matching row stored before node IDs were tracked (so the same
+// item isn't stored twice); callers pass HasUnclaimedDiscussionEvents, checked
+// once per poll. Returns true if a new row was inserted.
+func (s *Store) InsertDiscussionEvent(ctx context.Context, event *Event, claim bool) (bool, error) {
+	if claim {
+		claimed, err := s.ClaimLegacyDiscussionEvent(ctx, event)
+		if err != nil {
+			return false, err
+		}
+		if claimed {
+			return false, nil
+		}
+	}
+
+	if err := s.Insert(ctx, event); err != nil {
+		return false, err
+	}
+	return event.ID != "", nil
+}
+
+// HasUnclaimedDiscussionEvents reports whether any discussion rows still lack
+// a node key, so ClaimLegacyDiscussionEvent could match them: legacy GraphQL
+// rows and Events API discussion_created rows not yet seen over GraphQL
+func (s *Store) HasUnclaimedDiscussionEvents(ctx context.Context) (bool, error) {
+	var exists bool
+	err := s.pool.QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM events
+			WHERE discussion_number IS NOT NULL AND source_key IS NULL
+			  AND type IN ('discussion_created', 'discussion_comment', 'reaction')
+		)
+	`).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check unclaimed discussion events: %w", err)
+	}
+	return exists, nil
+}
+
+// ClaimLegacyDiscussionEvent attaches a GraphQL discussion event's node key and
+// identity to a matching row stored before node IDs were tracked, instead of
+// inserting a second copy. Rows are matched on (type, discussion_number,
+// github_user, occurred_at). Rows without a github_id (legacy GraphQL format,
+// see migration 013) also take the new payload; REST rows keep theirs.
+// Returns true if a row was claimed.
+func (s *Store) ClaimLegacyDiscussionEvent(ctx context.Context, event *Event) (bool, error) {
+	if event.SourceKey == nil || event.DiscussionNumber == nil {
+		return false, nil
+	}
+
+	query := `
+		UPDATE events SET
+			source_key = $1,
+			github_user_id = CASE WHEN github_user_id = 0 THEN $2::bigint ELSE github_user_id END,
+			comment_id = COALESCE($3::bigint, comment_id),
+			payload = CASE WHEN github_id IS NULL THEN $4::jsonb ELSE payload END,
+			content_hash = CASE WHEN github_id IS NULL THEN $5::varchar ELSE content_hash END
+		WHERE id = (
+			SELECT id FROM events
+			WHERE source_key IS NULL
+			  AND type = $6
+			  AND discussion_number = $7
+			  AND github_user = $8
+			  AND occurred_at = $9
+			LIMIT 1
+			FOR UPDATE
+		)
+		AND NOT EXISTS (SELECT 1 FROM events WHERE source_key = $1)
+		RETURNING id
+	`
+
+	var id string
+	err := s.pool.QueryRow(ctx, query,
+		*event.SourceKey, event.GitHubUserID, event.CommentID,
+		event.Payload, event.ContentHash,
+		event.Type, *event.DiscussionNumber, event.GitHubUser, event.OccurredAt,
+	).Scan(&id)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to claim legacy discussion event: %w", err)
+	}
+	return true, nil
+}
+
 // GetByID retrieves an event by its ID
 func (s *Store) GetByID(ctx context.Context, id string) (*Event, error) {
 	query := fmt.Sprintf(`SELECT %s FROM events WHERE id = $1`, eventColumns)
@@ -218,9 +557,105 @@ type ListFilters struct {
 	Since                   *time.Time
 	Until                   *time.Time
 	ExcludeCommentReactions bool // Hide reaction events that target comments (not PR/issue votes)
+	IncludeDeleted          bool // Include comments deleted on GitHub (tombstoned, see TombstoneComment)
+
+	userID       int64 // Account GitHubUser resolves to (see ResolveUser); 0 matches by login
+	userResolved bool
 }
 
-// List retrieves events with optional filters, sorting, and pagination
+// filterClause builds the " AND ..." SQL conditions for the given filters,
+// numbering placeholders from argPos. Shared by list, count and stream queries.
+func filterClause(filters *ListFilters, argPos int) (string, []interface{}) {
+	clause := ""
+	args := []interface{}{}
+	if filters == nil {
+		return clause, args
+	}
+
+	if len(filters.Types) > 0 {
+		clause += fmt.Sprintf(" AND type = ANY($%d)", argPos)
+		args = append(args, filters.Types)
+		argPos++
+	}
+	if filters.PRNumber != nil {
+		clause += fmt.Sprintf(" AND pr_number = $%d", argPos)
+		args = append(args, *filters.PRNumber)
+		argPos++
+	}
+	if filters.GitHubUser != nil {
+		cond, arg := userMatch(filters.userID, *filters.GitHubUser, argPos)
+		clause += " AND " + cond
+		args = append(args, arg)
+		argPos++
+	}
+	if filters.Since != nil {
+		clause += fmt.Sprintf(" AND occurred_at >= $%d", argPos)
+		args = append(args, *filters.Since)
+		argPos++
+	}
+	if filters.Until != nil {
+		clause += fmt.Sprintf(" AND occurred_at <= $%d", argPos)
+		args = append(args, *filters.Until)
+		argPos++
+	}
+	if filters.ExcludeCommentReactions {
+		clause += " AND NOT (type = 'reaction' AND comment_id IS NOT NULL)"
+	}
+	if !filters.IncludeDeleted {
+		clause += " AND deleted_at IS NULL"
+	}
+
+	return clause, args
+}
+
+// Matches reports whether an event satisfies the filters.
+// In-memory counterpart of filterClause, used for live stream fan-out.
+func (f *ListFilters) Matches(event *Event) bool {
+	if f == nil {
+		return true
+	}
+	if len(f.Types) > 0 {
+		found := false
+		for _, t := range f.Types {
+			if t == event.Type {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if f.PRNumber != nil && (event.PRNumber == nil || *event.PRNumber != *f.PRNumber) {
+		return false
+	}
+	if f.GitHubUser != nil {
+		if f.userID > 0 {
+			if event.GitHubUserID != f.userID {
+				return false
+			}
+		} else if event.GitHubUser != *f.GitHubUser {
+			return false
+		}
+	}
+	if f.Since != nil && event.OccurredAt.Before(*f.Since) {
+		return false
+	}
+	if f.Until != nil && event.OccurredAt.After(*f.Until) {
+		return false
+	}
+	if f.ExcludeCommentReactions && event.Type == EventReaction && event.CommentID != nil {
+		return false
+	}
+	if !f.IncludeDeleted && event.DeletedAt != nil {
+		return false
+	}
+	return true
+}
+
+// List retrieves events with optional filters, sorting, and pagination.
+// sort is "newest" (default), "oldest" or "relevance" (see listByRelevance).
+// The cursor is the value NextCursor returns for the last event of a page.
 func (s *Store) List(ctx context.Context, filters *ListFilters, sort string, limit int, cursor *string) ([]*Event, error) {
 	if limit <= 0 || limit > 100 {
 		limit = 50
@@ -230,42 +665,19 @@ func (s *Store) List(ctx context.Context, filters *ListFilters, sort string, lim
 
 // listInternal is the shared implementation for List and ExportList
 func (s *Store) listInternal(ctx context.Context, filters *ListFilters, sort string, limit int, cursor *string) ([]*Event, error) {
-	query := fmt.Sprintf(`SELECT %s FROM events WHERE 1=1`, eventColumns)
+	if err := s.ResolveUser(ctx, filters); err != nil {
+		return nil, err
+	}
+	if sort == "relevance" {
+		return s.listByRelevance(ctx, filters, limit, cursor)
+	}
 
-	args := []interface{}{}
-	argPos := 1
+	query := fmt.Sprintf(`SELECT %s FROM events WHERE 1=1`, eventColumns)
 
 	// Apply filters
-	if filters != nil {
-		if len(filters.Types) > 0 {
-			query += fmt.Sprintf(" AND type = ANY($%d)", argPos)
-			args = append(args, filters.Types)
-			argPos++
-		}
-		if filters.PRNumber != nil {
-			query += fmt.Sprintf(" AND pr_number = $%d", argPos)
-			args = append(args, *filters.PRNumber)
-			argPos++
-		}
-		if filters.GitHubUser != nil {
-			query += fmt.Sprintf(" AND github_user = $%d", argPos)
-			args = append(args, *filters.GitHubUser)
-			argPos++
-		}
-		if filters.Since != nil {
-			query += fmt.Sprintf(" AND occurred_at >= $%d", argPos)
-			args = append(args, *filters.Since)
-			argPos++
-		}
-		if filters.Until != nil {
-			query += fmt.Sprintf(" AND occurred_at <= $%d", argPos)
-			args = append(args, *filters.Until)
-			argPos++
-		}
-		if filters.ExcludeCommentReactions {
-			query += " AND NOT (type = 'reaction' AND comment_id IS NOT NULL)"
-		}
-	}
+	clause, args := filterClause(filters, 1)
+	query += clause
+	argPos := len(args) + 1
 
 	// Apply cursor for pagination (direction depends on sort)
 	if cursor != nil && *cursor != "" {
@@ -302,6 +714,40 @@ func (s *Store) listInternal(ctx context.Context, filters *ListFilters, sort str
 	return scanEvents(rows)
 }
 
+// ListIngestedAfter returns events ingested after the given event, oldest first.
+// Used to resume live streams from Last-Event-ID: a stream delivers events in
+// insertion order, and late-arriving events (e.g. polled reactions) can carry
+// an occurred_at older than events the client has already seen.
+func (s *Store) ListIngestedAfter(ctx context.Context, filters *ListFilters, afterID string, limit int) ([]*Event, error) {
+	if limit <= 0 || limit > 1000 {
+		limit = 500
+	}
+	if err := s.ResolveUser(ctx, filters); err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf(`SELECT %s FROM events WHERE 1=1`, eventColumns)
+	clause, args := filterClause(filters, 1)
+	query += clause
+
+	query += fmt.Sprintf(
+		" AND (ingested_at, id) > (SELECT ingested_at, id FROM events WHERE id = $%d)",
+		len(args)+1,
+	)
+	args = append(args, afterID)
+
+	query += fmt.Sprintf(" ORDER BY ingested_at ASC, id ASC LIMIT $%d", len(args)+1)
+	args = append(args, limit)
+
+	rows, err := s.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events after cursor: %w", err)
+	}
+	defer rows.Close()
+
+	return scanEvents(rows)
+}
+
 // ExportList retrieves events for bulk export with larger page sizes (max 1000).
 // Designed for research use — supports streaming large datasets via cursor pagination.
 func (s *Store) ExportList(ctx context.Context, filters *ListFilters, sort string, limit int, cursor *string) ([]*Event, error) {
@@ -324,11 +770,17 @@ func (s *Store) GetByPR(ctx context.Context, prNumber int) ([]*Event, error) {
 	return scanEvents(rows)
 }
 
-// GetByUser retrieves events for a specific GitHub user (capped at 500)
+// GetByUser retrieves events for a specific GitHub user (capped at 500),
+// including those recorded under the account's earlier logins
 func (s *Store) GetByUser(ctx context.Context, githubUser string) ([]*Event, error) {
-	query := fmt.Sprintf(`SELECT %s FROM events WHERE github_user = $1 ORDER BY occurred_at DESC LIMIT 500`, eventColumns)
+	userID, err := s.lookupUserID(ctx, githubUser)
+	if err != nil {
+		return nil, err
+	}
+	cond, arg := userMatch(userID, githubUser, 1)
+	query := fmt.Sprintf(`SELECT %s FROM events WHERE %s ORDER BY occurred_at DESC LIMIT 500`, eventColumns, cond)
 
-	rows, err := s.pool.Query(ctx, query, githubUser)
+	rows, err := s.pool.Query(ctx, query, arg)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get events for user: %w", err)
 	}
@@ -341,30 +793,18 @@ func (s *Store) GetByUser(ctx context.Context, githubUser string) ([]*Event, err
 // Uses "last vote wins" deduplication: if a user has both +1 and -1 on the
 // same PR, only their most recent vote counts (GitHub allows adding multiple
 // reaction types; we treat the latest as the user's final intent).
-func (s *Store) GetVoters(ctx context.Context) ([]*VoterSummary, error) {
-	query := `
-		WITH latest_votes AS (
-			SELECT DISTINCT ON (github_user, pr_number)
-				github_user, github_user_id, choice, pr_number, occurred_at
-			FROM events
-			WHERE type = 'reaction' AND choice IS NOT NULL AND comment_id IS NULL
-			ORDER BY github_user, pr_number, occurred_at DESC
-		)
-		SELECT
-			github_user,
-			github_user_id,
-			COUNT(*) as total_votes,
-			COUNT(*) FILTER (WHERE choice = 1) as upvotes,
-			COUNT(*) FILTER (WHERE choice = -1) as downvotes,
-			MIN(occurred_at) as first_vote,
-			MAX(occurred_at) as last_vote,
-			array_agg(DISTINCT pr_number ORDER BY pr_number) FILTER (WHERE pr_number IS NOT NULL) as prs_voted_on
-		FROM latest_votes
-		GROUP BY github_user, github_user_id
-		ORDER BY total_votes DESC
-	`
+// Voters are identified by account ID, so renames don't split them.
+// asOf restricts the aggregation to a point in time (nil for now); filters
+// narrows by account profile (nil for all voters).
+func (s *Store) GetVoters(ctx context.Context, asOf *PointInTime, filters *VoterFilters) ([]*VoterSummary, error) {
+	voteCond, args := asOf.voteClause(1)
+	historyCond, _ := asOf.eventClause(1) // Same $1 as voteCond
+	profileCond, profileArgs := filters.clause(len(args) + 1)
+	args = append(args, profileArgs...)
+
+	query := votersQuery(voteCond, historyCond, profileCond) + " ORDER BY v.total_votes DESC"
 
-	rows, err := s.pool.Query(ctx, query)
+	rows, err := s.pool.Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get voters: %w", err)
 	}
@@ -372,43 +812,113 @@ func (s *Store) GetVoters(ctx context.Context) ([]*VoterSummary, error) {
 
 	voters := []*VoterSummary{}
 	for rows.Next() {
-		voter := &VoterSummary{}
-
-		err := rows.Scan(
-			&voter.GitHubUser,
-			&voter.GitHubUserID,
-			&voter.TotalVotes,
-			&voter.Upvotes,
-			&voter.Downvotes,
-			&voter.FirstVote,
-			&voter.LastVote,
-			&voter.PRsVotedOn,
-		)
+		voter, err := scanVoter(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan voter: %w", err)
 		}
-
-		if voter.PRsVotedOn == nil {
-			voter.PRsVotedOn = []int{}
-		}
-		voter.UniquePRs = len(voter.PRsVotedOn)
-
 		voters = append(voters, voter)
 	}
 
 	return voters, nil
 }
 
+// votersQuery aggregates each voter's latest vote per PR into VoterSummary
+// columns followed by profileColumns (see scanVoter), displayed under their
+// latest login. voteCond filters vote rows; historyCond filters the vote
+// history (retracted votes included) that flips are counted from;
+// profileCond filters the joined github_users row (alias u).
+func votersQuery(voteCond, historyCond, profileCond string) string {
+	return `
+		WITH latest_votes AS (
+			SELECT DISTINCT ON (` + voterIdentity + `, pr_number)
+				github_user, github_user_id, choice, pr_number, occurred_at
+			FROM events
+			WHERE type = 'reaction' AND choice IS NOT NULL AND comment_id IS NULL` + voteCond + `
+			ORDER BY ` + voterIdentity + `, pr_number, occurred_at DESC
+		),
+		flips AS (
+			SELECT github_user_id, identity_login, COUNT(*) FILTER (WHERE choice <> previous) as flips
+			FROM (
+				SELECT github_user_id, CASE WHEN github_user_id > 0 THEN '' ELSE github_user END as identity_login, choice,
+					LAG(choice) OVER (PARTITION BY ` + voterIdentity + `, pr_number ORDER BY occurred_at) as previous
+				FROM events
+				WHERE type = 'reaction' AND choice IS NOT NULL AND comment_id IS NULL AND pr_number IS NOT NULL` + historyCond + `
+			) history
+			GROUP BY github_user_id, identity_login
+		),
+		voters AS (
+			SELECT
+				(array_agg(github_user ORDER BY occurred_at DESC))[1] as github_user,
+				github_user_id,
+				CASE WHEN github_user_id > 0 THEN '' ELSE github_user END as identity_login,
+				COUNT(*) as total_votes,
+				COUNT(*) FILTER (WHERE choice = 1) as upvotes,
+				COUNT(*) FILTER (WHERE choice = -1) as downvotes,
+				MIN(occurred_at) as first_vote,
+				MAX(occurred_at) as last_vote,
+				array_agg(DISTINCT pr_number ORDER BY pr_number) FILTER (WHERE pr_number IS NOT NULL) as prs_voted_on
+			FROM latest_votes
+			GROUP BY ` + voterIdentity + `
+		)
+		SELECT
+			COALESCE(l.login, v.github_user),
+			v.github_user_id,
+			v.total_votes,
+			v.upvotes,
+			v.downvotes,
+			v.first_vote,
+			v.last_vote,
+			v.prs_voted_on,
+			COALESCE(f.flips, 0),
+			` + profileColumns + `
+		FROM voters v
+		LEFT JOIN flips f ON f.github_user_id = v.github_user_id AND f.identity_login = v.identity_login
+		LEFT JOIN user_current_login l ON l.github_user_id = v.github_user_id
+		LEFT JOIN github_users u ON u.id = v.github_user_id
+		WHERE 1=1` + profileCond
+}
+
+// scanVoter scans a votersQuery row
+func scanVoter(row pgx.Row) (*VoterSummary, error) {
+	voter := &VoterSummary{}
+	var profile profileRow
+
+	err := row.Scan(append([]interface{}{
+		&voter.GitHubUser,
+		&voter.GitHubUserID,
+		&voter.TotalVotes,
+		&voter.Upvotes,
+		&voter.Downvotes,
+		&voter.FirstVote,
+		&voter.LastVote,
+		&voter.PRsVotedOn,
+		&voter.Flips,
+	}, profile.scanTargets()...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	if voter.PRsVotedOn == nil {
+		voter.PRsVotedOn = []int{}
+	}
+	voter.UniquePRs = len(voter.PRsVotedOn)
+	voter.Profile = profile.profile()
+
+	return voter, nil
+}
+
 // GetPRVotes retrieves vote breakdown for a specific PR.
-// Uses "last vote wins" deduplication per user.
-func (s *Store) GetPRVotes(ctx context.Context, prNumber int) (upvotes int, downvotes int, err error) {
+// Uses "last vote wins" deduplication per user. asOf restricts the tally to
+// a point in time (nil for now).
+func (s *Store) GetPRVotes(ctx context.Context, prNumber int, asOf *PointInTime) (upvotes int, downvotes int, err error) {
+	voteCond, args := asOf.voteClause(2)
 	query := `
 		WITH latest_votes AS (
-			SELECT DISTINCT ON (github_user)
+			SELECT DISTINCT ON (` + voterIdentity + `)
 				choice
 			FROM events
-			WHERE type = 'reaction' AND pr_number = $1 AND choice IS NOT NULL AND comment_id IS NULL
-			ORDER BY github_user, occurred_at DESC
+			WHERE type = 'reaction' AND pr_number = $1 AND choice IS NOT NULL AND comment_id IS NULL` + voteCond + `
+			ORDER BY ` + voterIdentity + `, occurred_at DESC
 		)
 		SELECT
 			COUNT(*) FILTER (WHERE choice = 1) as upvotes,
@@ -416,7 +926,7 @@ func (s *Store) GetPRVotes(ctx context.Context, prNumber int) (upvotes int, down
 		FROM latest_votes
 	`
 
-	err = s.pool.QueryRow(ctx, query, prNumber).Scan(&upvotes, &downvotes)
+	err = s.pool.QueryRow(ctx, query, append([]interface{}{prNumber}, args...)...).Scan(&upvotes, &downvotes)
 	if err != nil {
 		return 0, 0, fmt.Errorf("failed to get PR votes: %w", err)
 	}
@@ -435,29 +945,38 @@ type Stats struct {
 }
 
 // GetStats retrieves aggregate statistics for the feed.
-// Vote counts use "last vote wins" deduplication.
-func (s *Store) GetStats(ctx context.Context) (*Stats, error) {
+// Vote counts use "last vote wins" deduplication. asOf computes the stats as
+// of a point in time (nil for now); "last hour" is then the hour before it.
+func (s *Store) GetStats(ctx context.Context, asOf *PointInTime) (*Stats, error) {
+	voteCond, args := asOf.voteClause(1)
+	eventCond, _ := asOf.eventClause(1) // Same $1 as voteCond
+	now := "NOW()"
+	if asOf != nil {
+		now = "$1::timestamptz"
+	}
+
 	query := `
 		SELECT
 			COUNT(*) as total_events,
 			(SELECT COUNT(*) FROM (
-				SELECT DISTINCT ON (github_user, pr_number) 1
+				SELECT DISTINCT ON (` + voterIdentity + `, pr_number) 1
 				FROM events
-				WHERE type = 'reaction' AND choice IS NOT NULL AND comment_id IS NULL
-				ORDER BY github_user, pr_number, occurred_at DESC
+				WHERE type = 'reaction' AND choice IS NOT NULL AND comment_id IS NULL` + voteCond + `
+				ORDER BY ` + voterIdentity + `, pr_number, occurred_at DESC
 			) deduped) as total_votes,
-			(SELECT COUNT(DISTINCT github_user) FROM events
-				WHERE type = 'reaction' AND choice IS NOT NULL AND comment_id IS NULL) as total_voters,
+			(SELECT COUNT(DISTINCT (` + voterIdentity + `)) FROM events
+				WHERE type = 'reaction' AND choice IS NOT NULL AND comment_id IS NULL` + voteCond + `) as total_voters,
 			MAX(occurred_at) as latest_event,
-			COUNT(*) FILTER (WHERE occurred_at > NOW() - INTERVAL '1 hour') as events_last_hour
+			COUNT(*) FILTER (WHERE occurred_at > ` + now + ` - INTERVAL '1 hour') as events_last_hour
 		FROM events
+		WHERE 1=1` + eventCond + `
 	`
 
 	stats := &Stats{
 		EventsByType: make(map[string]int),
 	}
 
-	err := s.pool.QueryRow(ctx, query).Scan(
+	err := s.pool.QueryRow(ctx, query, args...).Scan(
 		&stats.TotalEvents,
 		&stats.TotalVotes,
 		&stats.TotalVoters,
@@ -472,11 +991,12 @@ func (s *Store) GetStats(ctx context.Context) (*Stats, error) {
 	typeQuery := `
 		SELECT type, COUNT(*) as count
 		FROM events
+		WHERE 1=1` + eventCond + `
 		GROUP BY type
 		ORDER BY count DESC
 	`
 
-	rows, err := s.pool.Query(ctx, typeQuery)
+	rows, err := s.pool.Query(ctx, typeQuery, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get events by type: %w", err)
 	}
@@ -508,42 +1028,20 @@ func (s *Store) GetByIssue(ctx context.Context, issueNumber int) ([]*Event, erro
 }
 
 // GetVoter retrieves aggregated voting statistics for a single voter.
-// Uses "last vote wins" deduplication per PR.
-func (s *Store) GetVoter(ctx context.Context, githubUser string) (*VoterSummary, error) {
-	query := `
-		WITH latest_votes AS (
-			SELECT DISTINCT ON (pr_number)
-				github_user, github_user_id, choice, pr_number, occurred_at
-			FROM events
-			WHERE type = 'reaction' AND choice IS NOT NULL AND comment_id IS NULL AND github_user = $1
-			ORDER BY pr_number, occurred_at DESC
-		)
-		SELECT
-			github_user,
-			github_user_id,
-			COUNT(*) as total_votes,
-			COUNT(*) FILTER (WHERE choice = 1) as upvotes,
-			COUNT(*) FILTER (WHERE choice = -1) as downvotes,
-			MIN(occurred_at) as first_vote,
-			MAX(occurred_at) as last_vote,
-			array_agg(DISTINCT pr_number ORDER BY pr_number) FILTER (WHERE pr_number IS NOT NULL) as prs_voted_on
-		FROM latest_votes
-		GROUP BY github_user, github_user_id
-	`
-
-	voter := &VoterSummary{}
-
-	err := s.pool.QueryRow(ctx, query, githubUser).Scan(
-		&voter.GitHubUser,
-		&voter.GitHubUserID,
-		&voter.TotalVotes,
-		&voter.Upvotes,
-		&voter.Downvotes,
-		&voter.FirstVote,
-		&voter.LastVote,
-		&voter.PRsVotedOn,
-	)
+// Uses "last vote wins" deduplication per PR. githubUser may be any login the
+// account has used; Aliases lists them all. asOf restricts the aggregation to
+// a point in time (nil for now).
+func (s *Store) GetVoter(ctx context.Context, githubUser string, asOf *PointInTime) (*VoterSummary, error) {
+	userID, err := s.lookupUserID(ctx, githubUser)
+	if err != nil {
+		return nil, err
+	}
+	user, arg := userMatch(userID, githubUser, 1)
+	voteCond, args := asOf.voteClause(2)
+	historyCond, _ := asOf.eventClause(2) // Same $2 as voteCond
+	query := votersQuery(" AND "+user+voteCond, " AND "+user+historyCond, "")
 
+	voter, err := scanVoter(s.pool.QueryRow(ctx, query, append([]interface{}{arg}, args...)...))
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return nil, fmt.Errorf("voter not found: %s", githubUser)
@@ -551,38 +1049,69 @@ func (s *Store) GetVoter(ctx context.Context, githubUser string) (*VoterSummary,
 		return nil, fmt.Errorf("failed to get voter: %w", err)
 	}
 
-	if voter.PRsVotedOn == nil {
-		voter.PRsVotedOn = []int{}
+	if voter.GitHubUserID > 0 {
+		voter.Aliases, err = s.GetUserAliases(ctx, voter.GitHubUserID)
+		if err != nil {
+			return nil, err
+		}
 	}
-	voter.UniquePRs = len(voter.PRsVotedOn)
 
 	return voter, nil
 }
 
+// Voter relationships to a PR, strongest first
+const (
+	RelationAuthor    = "author"
+	RelationReviewer  = "reviewer"  // Submitted a review or review comment
+	RelationCommenter = "commenter" // Commented on the PR conversation
+	RelationNone      = "none"
+)
+
 // VoteDetail represents detailed vote information
 type VoteDetail struct {
 	GitHubUser   string
 	GitHubUserID int64
 	Choice       int8
 	OccurredAt   time.Time
+	Relationship string // Relation* constant, from the PR's events as of the same point in time
 }
 
 // GetPRVoteDetails retrieves detailed vote information for a PR.
-// Uses "last vote wins" deduplication per user.
-func (s *Store) GetPRVoteDetails(ctx context.Context, prNumber int) ([]*VoteDetail, error) {
+// Uses "last vote wins" deduplication per user. asOf restricts the votes to
+// a point in time (nil for now).
+func (s *Store) GetPRVoteDetails(ctx context.Context, prNumber int, asOf *PointInTime) ([]*VoteDetail, error) {
+	voteCond, args := asOf.voteClause(2)
+	eventCond, _ := asOf.eventClause(2) // Same $2 as voteCond
+	participated := func(types string) string {
+		return `EXISTS (SELECT 1 FROM events e WHERE e.pr_number = $1 AND e.type IN (` + types + `) AND ` + sameVoter + eventCond + `)`
+	}
+
 	query := `
-		SELECT github_user, github_user_id, choice, occurred_at
+		SELECT COALESCE(l.login, v.github_user), v.github_user_id, v.choice, v.occurred_at,
+			CASE
+				WHEN EXISTS (
+					SELECT 1 FROM prs p
+					WHERE p.number = $1 AND CASE
+						WHEN p.author_id > 0 AND v.github_user_id > 0 THEN p.author_id = v.github_user_id
+						ELSE p.author = v.github_user -- Logins only when an ID is unknown
+					END
+				) OR ` + participated(`'pr_opened'`) + ` THEN '` + RelationAuthor + `'
+				WHEN ` + participated(`'review_submitted', 'review_comment'`) + ` THEN '` + RelationReviewer + `'
+				WHEN ` + participated(`'issue_comment'`) + ` THEN '` + RelationCommenter + `'
+				ELSE '` + RelationNone + `'
+			END
 		FROM (
-			SELECT DISTINCT ON (github_user)
+			SELECT DISTINCT ON (` + voterIdentity + `)
 				github_user, github_user_id, choice, occurred_at
 			FROM events
-			WHERE type = 'reaction' AND pr_number = $1 AND choice IS NOT NULL AND comment_id IS NULL
-			ORDER BY github_user, occurred_at DESC
-		) latest
-		ORDER BY occurred_at ASC
+			WHERE type = 'reaction' AND pr_number = $1 AND choice IS NOT NULL AND comment_id IS NULL` + voteCond + `
+			ORDER BY ` + voterIdentity + `, occurred_at DESC
+		) v
+		LEFT JOIN user_current_login l ON l.github_user_id = v.github_user_id
+		ORDER BY v.occurred_at ASC
 	`
 
-	rows, err := s.pool.Query(ctx, query, prNumber)
+	rows, err := s.pool.Query(ctx, query, append([]interface{}{prNumber}, args...)...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get PR vote details: %w", err)
 	}
@@ -596,6 +1125,7 @@ func (s *Store) GetPRVoteDetails(ctx context.Context, prNumber int) ([]*VoteDeta
 			&detail.GitHubUserID,
 			&detail.Choice,
 			&detail.OccurredAt,
+			&detail.Relationship,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan vote detail: %w", err)
@@ -616,7 +1146,7 @@ func (s *Store) GetCommentReactionCounts(ctx context.Context, commentIDs []int64
 	query := `
 		SELECT comment_id, reaction_type, COUNT(*) as cnt
 		FROM events
-		WHERE type = 'reaction' AND comment_id = ANY($1) AND reaction_type IS NOT NULL
+		WHERE type = 'reaction' AND comment_id = ANY($1) AND reaction_type IS NOT NULL AND retracted_at IS NULL
 		GROUP BY comment_id, reaction_type
 	`
 
@@ -654,7 +1184,7 @@ func (s *Store) GetPRReactionCounts(ctx context.Context, prNumbers []int) (map[i
 	query := `
 		SELECT pr_number, reaction_type, COUNT(*) as cnt
 		FROM events
-		WHERE type = 'reaction' AND pr_number = ANY($1) AND comment_id IS NULL AND reaction_type IS NOT NULL
+		WHERE type = 'reaction' AND pr_number = ANY($1) AND comment_id IS NULL AND reaction_type IS NOT NULL AND retracted_at IS NULL
 		GROUP BY pr_number, reaction_type
 	`
 
@@ -681,6 +1211,187 @@ func (s *Store) GetPRReactionCounts(ctx context.Context, prNumbers []int) (map[i
 	return result, nil
 }
 
+// ReactionTarget identifies what a set of REST reactions belongs to:
+// a PR, an issue, or an issue/PR comment
+type ReactionTarget struct {
+	Kind      string // "pr", "issue" or "comment"
+	Number    int    // PR or issue number (pr, issue)
+	CommentID int64  // Comment ID (comment)
+}
+
+// PRReactions targets PR-level reactions (the votes)
+func PRReactions(number int) ReactionTarget {
+	return ReactionTarget{Kind: "pr", Number: number}
+}
+
+// IssueReactions targets issue-level reactions
+func IssueReactions(number int) ReactionTarget {
+	return ReactionTarget{Kind: "issue", Number: number}
+}
+
+// CommentReactions targets reactions on an issue or PR comment
+func CommentReactions(commentID int64) ReactionTarget {
+	return ReactionTarget{Kind: "comment", CommentID: commentID}
+}
+
+// String formats the target for logs, e.g. "pr#12" or "comment:123"
+func (t ReactionTarget) String() string {
+	if t.Kind == "comment" {
+		return fmt.Sprintf("comment:%d", t.CommentID)
+	}
+	return fmt.Sprintf("%s#%d", t.Kind, t.Number)
+}
+
+// scope returns the SQL condition selecting the target's reaction rows, the
+// argument it binds, and the payload key naming the target
+func (t ReactionTarget) scope(argPos int) (string, interface{}, string) {
+	switch t.Kind {
+	case "issue":
+		return fmt.Sprintf("issue_number = $%d AND comment_id IS NULL", argPos), t.Number, "issue_number"
+	case "comment":
+		// Discussion comment IDs are a separate ID space (GraphQL databaseId)
+		return fmt.Sprintf("comment_id = $%d AND discussion_number IS NULL", argPos), t.CommentID, "comment_id"
+	default:
+		return fmt.Sprintf("pr_number = $%d AND comment_id IS NULL", argPos), t.Number, "pr_number"
+	}
+}
+
+// apply sets the target's columns on an event
+func (t ReactionTarget) apply(event *Event) {
+	switch t.Kind {
+	case "issue":
+		number := t.Number
+		event.IssueNumber = &number
+	case "comment":
+		commentID := t.CommentID
+		event.CommentID = &commentID
+	default:
+		number := t.Number
+		event.PRNumber = &number
+	}
+}
+
+// RetractMissingReactions reconciles a target's stored reactions against the
+// set GitHub currently reports. Each stored reaction whose ID is absent from
+// currentIDs is stamped with retracted_at, and a reaction_removed event linking
+// back to it is recorded. The original row is kept as public record.
+// Returns the reaction_removed events that were created.
+func (s *Store) RetractMissingReactions(ctx context.Context, target ReactionTarget, currentIDs []int64, detectedAt time.Time) ([]*Event, error) {
+	if currentIDs == nil {
+		currentIDs = []int64{} // NULL would make the NOT ANY() check match nothing
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin reaction reconciliation: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	scope, scopeArg, payloadKey := target.scope(1)
+	query := `
+		SELECT id, github_user, github_user_id, choice, reaction_type, github_id, occurred_at
+		FROM events
+		WHERE type = 'reaction' AND ` + scope + `
+		  AND github_id IS NOT NULL AND retracted_at IS NULL
+		  AND NOT (github_id = ANY($2))
+		FOR UPDATE
+	`
+
+	rows, err := tx.Query(ctx, query, scopeArg, currentIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find stale reactions: %w", err)
+	}
+
+	type staleReaction struct {
+		id           string
+		githubUser   string
+		githubUserID int64
+		choice       *int8
+		reactionType *string
+		githubID     int64
+		occurredAt   time.Time
+	}
+	var stale []staleReaction
+	for rows.Next() {
+		var r staleReaction
+		if err := rows.Scan(&r.id, &r.githubUser, &r.githubUserID, &r.choice, &r.reactionType, &r.githubID, &r.occurredAt); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan stale reaction: %w", err)
+		}
+		stale = append(stale, r)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read stale reactions: %w", err)
+	}
+
+	removed := make([]*Event, 0, len(stale))
+	for _, r := range stale {
+		payload, _ := json.Marshal(map[string]interface{}{
+			"reaction_id": r.githubID,
+			"content":     r.reactionType,
+			"user":        map[string]interface{}{"login": r.githubUser, "id": r.githubUserID},
+			"reacted_at":  r.occurredAt,
+			"detected_at": detectedAt,
+			payloadKey:    scopeArg,
+		})
+
+		originalID := r.id
+		event := &Event{
+			Type:           EventReactionRemoved,
+			GitHubUser:     r.githubUser,
+			GitHubUserID:   r.githubUserID,
+			Choice:         r.choice,
+			ReactionType:   r.reactionType,
+			RelatedEventID: &originalID,
+			Payload:        payload,
+			ContentHash:    computeContentHash(payload),
+			OccurredAt:     detectedAt,
+		}
+		target.apply(event)
+		if err := insertEvent(ctx, tx, event); err != nil {
+			return nil, err
+		}
+
+		if _, err := tx.Exec(ctx, `UPDATE events SET retracted_at = $2 WHERE id = $1`, r.id, detectedAt); err != nil {
+			return nil, fmt.Errorf("failed to mark reaction retracted: %w", err)
+		}
+		removed = append(removed, event)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit reaction reconciliation: %w", err)
+	}
+
+	return removed, nil
+}
+
+// ListCommentIDs returns IDs of issue/PR comments created at or after since,
+// newest first. Used to pick which comments' reactions to poll.
+func (s *Store) ListCommentIDs(ctx context.Context, since time.Time) ([]int64, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT comment_id
+		FROM events
+		WHERE type = 'issue_comment' AND comment_id IS NOT NULL AND occurred_at >= $1
+		GROUP BY comment_id
+		ORDER BY MAX(occurred_at) DESC
+	`, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list comment IDs: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan comment ID: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
 // NormalizeReactionTypes fixes uppercase GraphQL reaction types in existing data.
 // Converts THUMBS_UP → +1, THUMBS_DOWN → -1, etc. and sets choice accordingly.
 func (s *Store) NormalizeReactionTypes(ctx context.Context) (int64, error) {
@@ -712,41 +1423,13 @@ func (s *Store) NormalizeReactionTypes(ctx context.Context) (int64, error) {
 
 // Count returns the total number of events matching the filters
 func (s *Store) Count(ctx context.Context, filters *ListFilters) (int, error) {
+	if err := s.ResolveUser(ctx, filters); err != nil {
+		return 0, err
+	}
 	query := `SELECT COUNT(*) FROM events WHERE 1=1`
 
-	args := []interface{}{}
-	argPos := 1
-
-	if filters != nil {
-		if len(filters.Types) > 0 {
-			query += fmt.Sprintf(" AND type = ANY($%d)", argPos)
-			args = append(args, filters.Types)
-			argPos++
-		}
-		if filters.PRNumber != nil {
-			query += fmt.Sprintf(" AND pr_number = $%d", argPos)
-			args = append(args, *filters.PRNumber)
-			argPos++
-		}
-		if filters.GitHubUser != nil {
-			query += fmt.Sprintf(" AND github_user = $%d", argPos)
-			args = append(args, *filters.GitHubUser)
-			argPos++
-		}
-		if filters.Since != nil {
-			query += fmt.Sprintf(" AND occurred_at >= $%d", argPos)
-			args = append(args, *filters.Since)
-			argPos++
-		}
-		if filters.Until != nil {
-			query += fmt.Sprintf(" AND occurred_at <= $%d", argPos)
-			args = append(args, *filters.Until)
-			argPos++
-		}
-		if filters.ExcludeCommentReactions {
-			query += " AND NOT (type = 'reaction' AND comment_id IS NOT NULL)"
-		}
-	}
+	clause, args := filterClause(filters, 1)
+	query += clause
 
 	var count int
 	err := s.pool.QueryRow(ctx, query, args...).Scan(&count)
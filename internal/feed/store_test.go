@@ -34,6 +34,28 @@ func TestStore_GetVoters(t *testing.T) {
 	// 3. Verify aggregated stats match expectations
 }
 
+// TestListFilters_MatchesUser checks the stream matcher follows a resolved
+// account across renames and falls back to the login otherwise
+func TestListFilters_MatchesUser(t *testing.T) {
+	login := "old-name"
+	renamed := createTestEvent("new-name", 1, 1) // GitHubUserID 12345
+	other := createTestEvent("old-name", 1, 1)
+	other.GitHubUserID = 999 // Login since reclaimed by another account
+
+	resolved := &ListFilters{GitHubUser: &login, userID: 12345, userResolved: true}
+	if !resolved.Matches(renamed) {
+		t.Error("resolved filter should match the account under its new login")
+	}
+	if resolved.Matches(other) {
+		t.Error("resolved filter should not match another account using the login")
+	}
+
+	unresolved := &ListFilters{GitHubUser: &login, userResolved: true}
+	if unresolved.Matches(renamed) || !unresolved.Matches(other) {
+		t.Error("filter without an account should match by login")
+	}
+}
+
 // Helper function to create test events
 func createTestEvent(githubUser string, prNumber int, choice int8) *Event {
 	payload := []byte(`{"test": true}`)
@@ -0,0 +1,784 @@
+package feed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/skridlevsky/openchaos-feed/internal/github"
+)
+
+// Sync steps in run order. Names are accepted by cmd/backfill --steps and
+// name each step's checkpoint in ingester_state.
+const (
+	StepPRs              = "prs"
+	StepIssues           = "issues"
+	StepPRReactions      = "pr_reactions"
+	StepIssueReactions   = "issue_reactions"
+	StepComments         = "comments"
+	StepCommentReactions = "comment_reactions"
+	StepStars            = "stars"
+	StepForks            = "forks"
+	StepDiscussions      = "discussions"
+)
+
+// SyncSteps lists every sync step in run order
+var SyncSteps = []string{
+	StepPRs, StepIssues, StepPRReactions, StepIssueReactions,
+	StepComments, StepCommentReactions, StepStars, StepForks, StepDiscussions,
+}
+
+// checkpointEvery is how many items a step processes between checkpoint saves
+const checkpointEvery = 25
+
+// stateSyncRun records the options of the current or last sync run
+const stateSyncRun = "sync.run"
+
+// SyncOptions selects what a sync run covers
+type SyncOptions struct {
+	Steps   []string  // Subset of SyncSteps; empty runs all
+	Since   time.Time // Only items created or updated since; zero syncs full history
+	Restart bool      // Discard checkpoints of an unfinished run instead of resuming it
+}
+
+// Syncer pulls repository history from the REST and GraphQL APIs into the
+// store. Each step processes items in ascending key order (PR number, comment
+// ID, ...) and checkpoints the last key it finished, so an interrupted run
+// resumes where it stopped. Used by cmd/backfill for full syncs and by the
+// ingester to fill Events API gaps.
+type Syncer struct {
+	githubClient  *github.Client
+	graphqlClient GraphQLClient
+	store         *Store
+	owner         string
+	repo          string
+	lists         *syncLists // Lists fetched by the current Run; nil outside one
+}
+
+// syncLists holds the item lists a run has fetched, so the reaction steps reuse
+// the lists the prs, issues and comments steps paged through instead of
+// fetching them again. Every step of a run shares its since.
+type syncLists struct {
+	prs      []*github.GitHubPR
+	issues   []github.GitHubIssue
+	comments []github.GitHubComment
+}
+
+// NewSyncer creates a new sync engine.
+// Returns an error if ownerRepo is not in "owner/repo" format.
+func NewSyncer(githubClient *github.Client, graphqlClient GraphQLClient, store *Store, ownerRepo string) (*Syncer, error) {
+	parts := strings.Split(ownerRepo, "/")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid repo format: %s (expected owner/repo)", ownerRepo)
+	}
+
+	return &Syncer{
+		githubClient:  githubClient,
+		graphqlClient: graphqlClient,
+		store:         store,
+		owner:         parts[0],
+		repo:          parts[1],
+	}, nil
+}
+
+// syncRun identifies a run so an interrupted one is resumed only with the same options
+type syncRun struct {
+	Steps     []string  `json:"steps"`
+	Since     time.Time `json:"since"`
+	StartedAt time.Time `json:"startedAt"`
+	Done      bool      `json:"done"`
+}
+
+// syncCheckpoint is one step's progress within a run
+type syncCheckpoint struct {
+	LastKey   int64 `json:"lastKey"` // Last item key fully processed
+	Processed int   `json:"processed"`
+	Done      bool  `json:"done"`
+}
+
+// checkpointKey is the ingester_state key for a step's checkpoint
+func checkpointKey(step string) string {
+	return "sync.step." + step
+}
+
+// Run executes the selected steps. If the previous run with the same steps and
+// since was interrupted, it resumes from each step's checkpoint unless
+// opts.Restart is set. Returns on the first failed step; its checkpoint is
+// kept, so running again continues from there.
+func (s *Syncer) Run(ctx context.Context, opts SyncOptions) error {
+	steps := SyncSteps
+	if len(opts.Steps) > 0 {
+		for _, step := range opts.Steps {
+			if !slices.Contains(SyncSteps, step) {
+				return fmt.Errorf("unknown sync step: %s (valid: %s)", step, strings.Join(SyncSteps, ", "))
+			}
+		}
+		// Keep canonical order: reaction steps read the lists earlier steps store
+		steps = slices.DeleteFunc(slices.Clone(SyncSteps), func(step string) bool {
+			return !slices.Contains(opts.Steps, step)
+		})
+	}
+
+	var run syncRun
+	found, err := s.store.LoadState(ctx, stateSyncRun, &run)
+	if err != nil {
+		return err
+	}
+
+	resume := found && !run.Done && !opts.Restart &&
+		slices.Equal(run.Steps, steps) && run.Since.Equal(opts.Since)
+	if resume {
+		slog.Info("Resuming interrupted sync", "steps", steps, "since", opts.Since, "started_at", run.StartedAt)
+	} else {
+		run = syncRun{Steps: steps, Since: opts.Since, StartedAt: time.Now()}
+		for _, step := range steps {
+			if err := s.store.SaveState(ctx, checkpointKey(step), syncCheckpoint{}); err != nil {
+				return err
+			}
+		}
+		if err := s.store.SaveState(ctx, stateSyncRun, run); err != nil {
+			return err
+		}
+		slog.Info("Starting sync", "steps", steps, "since", opts.Since)
+	}
+
+	s.lists = &syncLists{}
+	defer func() { s.lists = nil }()
+
+	for i, step := range steps {
+		progress := &stepProgress{store: s.store, step: step}
+		if _, err := s.store.LoadState(ctx, checkpointKey(step), &progress.cp); err != nil {
+			return err
+		}
+		if progress.cp.Done {
+			slog.Info("Sync step already complete", "step", step)
+			continue
+		}
+
+		slog.Info("Sync step starting",
+			"step", step,
+			"position", fmt.Sprintf("%d/%d", i+1, len(steps)),
+			"resume_after_key", progress.cp.LastKey,
+		)
+
+		if err := s.runStep(ctx, step, opts.Since, progress); err != nil {
+			progress.save(ctx)
+			return fmt.Errorf("sync step %s failed (re-run to resume): %w", step, err)
+		}
+
+		progress.cp.Done = true
+		progress.save(ctx)
+		slog.Info("Sync step complete",
+			"step", step,
+			"items_processed", progress.cp.Processed,
+			"events_inserted", progress.inserted,
+		)
+	}
+
+	s.cleanup(ctx)
+
+	run.Done = true
+	return s.store.SaveState(ctx, stateSyncRun, run)
+}
+
+// runStep dispatches a step by name
+func (s *Syncer) runStep(ctx context.Context, step string, since time.Time, p *stepProgress) error {
+	switch step {
+	case StepPRs:
+		return s.syncPRs(ctx, since, p)
+	case StepIssues:
+		return s.syncIssues(ctx, since, p)
+	case StepPRReactions:
+		return s.syncPRReactions(ctx, since, p)
+	case StepIssueReactions:
+		return s.syncIssueReactions(ctx, since, p)
+	case StepComments:
+		return s.syncComments(ctx, since, p)
+	case StepCommentReactions:
+		return s.syncCommentReactions(ctx, since, p)
+	case StepStars:
+		return s.syncStars(ctx, since, p)
+	case StepForks:
+		return s.syncForks(ctx, since, p)
+	case StepDiscussions:
+		return s.syncDiscussions(ctx, since, p)
+	}
+	return fmt.Errorf("unknown sync step: %s", step)
+}
+
+// stepProgress tracks and checkpoints one step's progress
+type stepProgress struct {
+	store     *Store
+	step      string
+	cp        syncCheckpoint
+	inserted  int
+	sinceSave int
+}
+
+// pending reports whether the item with this key still needs processing
+func (p *stepProgress) pending(key int64) bool {
+	return key > p.cp.LastKey
+}
+
+// insert stores an event, counting new rows. Failures are logged and skipped
+// like the rest of the ingestion paths; dedup makes a later retry safe.
+func (p *stepProgress) insert(ctx context.Context, event *Event) {
+	if err := p.store.Insert(ctx, event); err != nil {
+		slog.Warn("Failed to insert event", "step", p.step, "type", event.Type, "error", err)
+		return
+	}
+	if event.ID != "" {
+		p.inserted++
+	}
+}
+
+// upsert rewrites the event's stored row if it's still in the old flat
+// payload format, keeping its history, and inserts it otherwise
+func (p *stepProgress) upsert(ctx context.Context, event *Event, types []EventType) {
+	replaced, err := p.store.ReplaceFlatPayload(ctx, event, types)
+	if err != nil {
+		slog.Warn("Failed to replace flat payload", "step", p.step, "type", event.Type, "error", err)
+		return
+	}
+	if !replaced {
+		p.insert(ctx, event)
+	}
+}
+
+// done marks the item with this key processed, saving the checkpoint periodically
+func (p *stepProgress) done(ctx context.Context, key int64, total int) {
+	p.cp.LastKey = key
+	p.cp.Processed++
+	p.sinceSave++
+	if p.sinceSave >= checkpointEvery {
+		p.save(ctx)
+		slog.Info("Sync progress", "step", p.step, "processed", p.cp.Processed, "total", total)
+	}
+}
+
+// save persists the checkpoint, even if ctx was cancelled mid-step
+func (p *stepProgress) save(ctx context.Context) {
+	if err := p.store.SaveState(context.WithoutCancel(ctx), checkpointKey(p.step), p.cp); err != nil {
+		slog.Warn("Failed to save sync checkpoint", "step", p.step, "error", err)
+	}
+	p.sinceSave = 0
+}
+
+// listPRs returns PRs updated since (all if zero), ascending by number.
+// Fetched once per run.
+func (s *Syncer) listPRs(ctx context.Context, since time.Time) ([]*github.GitHubPR, error) {
+	if s.lists != nil && s.lists.prs != nil {
+		return s.lists.prs, nil
+	}
+
+	var prs []*github.GitHubPR
+	var err error
+	if since.IsZero() {
+		prs, err = s.githubClient.GetAllPRs(ctx, s.owner, s.repo)
+	} else {
+		prs, err = s.githubClient.GetPRsUpdatedSince(ctx, s.owner, s.repo, since)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch PRs: %w", err)
+	}
+	sort.Slice(prs, func(i, j int) bool { return prs[i].Number < prs[j].Number })
+	if s.lists != nil {
+		s.lists.prs = prs
+	}
+	return prs, nil
+}
+
+// listIssues returns issues (not PRs) updated since (all if zero), ascending
+// by number. Fetched once per run.
+func (s *Syncer) listIssues(ctx context.Context, since time.Time) ([]github.GitHubIssue, error) {
+	if s.lists != nil && s.lists.issues != nil {
+		return s.lists.issues, nil
+	}
+
+	var issues []github.GitHubIssue
+	var err error
+	if since.IsZero() {
+		issues, err = s.githubClient.GetAllIssues(ctx, s.owner, s.repo)
+	} else {
+		issues, err = s.githubClient.GetIssuesUpdatedSince(ctx, s.owner, s.repo, since)
+		issues = slices.DeleteFunc(issues, func(issue github.GitHubIssue) bool {
+			return issue.PullRequest != nil
+		})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch issues: %w", err)
+	}
+	sort.Slice(issues, func(i, j int) bool { return issues[i].Number < issues[j].Number })
+	if s.lists != nil {
+		s.lists.issues = issues
+	}
+	return issues, nil
+}
+
+// listComments returns issue/PR comments updated since (all if zero),
+// ascending by ID. Fetched once per run.
+func (s *Syncer) listComments(ctx context.Context, since time.Time) ([]github.GitHubComment, error) {
+	if s.lists != nil && s.lists.comments != nil {
+		return s.lists.comments, nil
+	}
+
+	comments, err := s.githubClient.GetCommentsSince(ctx, s.owner, s.repo, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch comments: %w", err)
+	}
+	sort.Slice(comments, func(i, j int) bool { return comments[i].ID < comments[j].ID })
+	if s.lists != nil {
+		s.lists.comments = comments
+	}
+	return comments, nil
+}
+
+// syncPRs stores one state event per PR (see PRStateEvent)
+func (s *Syncer) syncPRs(ctx context.Context, since time.Time, p *stepProgress) error {
+	prs, err := s.listPRs(ctx, since)
+	if err != nil {
+		return err
+	}
+	slog.Info("Sync: PRs fetched", "count", len(prs))
+
+	// Same flat-payload replacement as syncComments. Opening rows carry the
+	// edit history and transition rows (keyed by source_key) the PR's real
+	// lifecycle, so neither is deleted wholesale.
+	prTypes := []EventType{EventPROpened, EventPRClosed, EventPRMerged, EventPRReopened}
+	if since.IsZero() && p.cp.LastKey == 0 {
+		deleted, err := s.store.DeleteUnkeyedFlatEvents(ctx, prTypes)
+		if err != nil {
+			return fmt.Errorf("failed to delete old PR events: %w", err)
+		}
+		if deleted > 0 {
+			slog.Info("Sync: deleted old PR events for re-insert", "count", deleted)
+		}
+	}
+
+	for i, pr := range prs {
+		if !p.pending(int64(pr.Number)) {
+			continue
+		}
+		p.upsert(ctx, PRStateEvent(pr), prTypes)
+		if err := s.store.UpsertPR(ctx, PullRequestFromREST(pr)); err != nil {
+			slog.Warn("Failed to update PR state", "pr", pr.Number, "error", err)
+		}
+		p.done(ctx, int64(pr.Number), len(prs))
+
+		if (i+1)%50 == 0 {
+			s.checkRateLimit(ctx)
+		}
+	}
+	return nil
+}
+
+// syncIssues stores one state event per issue (see IssueStateEvent)
+func (s *Syncer) syncIssues(ctx context.Context, since time.Time, p *stepProgress) error {
+	issues, err := s.listIssues(ctx, since)
+	if err != nil {
+		return err
+	}
+	slog.Info("Sync: issues fetched", "count", len(issues))
+
+	// Same flat-payload replacement as syncPRs
+	issueTypes := []EventType{EventIssueOpened, EventIssueClosed, EventIssueReopened}
+	if since.IsZero() && p.cp.LastKey == 0 {
+		deleted, err := s.store.DeleteUnkeyedFlatEvents(ctx, issueTypes)
+		if err != nil {
+			return fmt.Errorf("failed to delete old issue events: %w", err)
+		}
+		if deleted > 0 {
+			slog.Info("Sync: deleted old issue events for re-insert", "count", deleted)
+		}
+	}
+
+	for i := range issues {
+		issue := &issues[i]
+		if !p.pending(int64(issue.Number)) {
+			continue
+		}
+		p.upsert(ctx, IssueStateEvent(issue), issueTypes)
+		if err := s.store.UpsertIssue(ctx, IssueFromREST(issue)); err != nil {
+			slog.Warn("Failed to update issue state", "issue", issue.Number, "error", err)
+		}
+		p.done(ctx, int64(issue.Number), len(issues))
+	}
+	return nil
+}
+
+// syncPRReactions stores reactions on PRs (THE VOTES!)
+func (s *Syncer) syncPRReactions(ctx context.Context, since time.Time, p *stepProgress) error {
+	prs, err := s.listPRs(ctx, since)
+	if err != nil {
+		return err
+	}
+
+	for i, pr := range prs {
+		if !p.pending(int64(pr.Number)) {
+			continue
+		}
+		s.syncReactions(ctx, PRReactions(pr.Number), p)
+		p.done(ctx, int64(pr.Number), len(prs))
+
+		if (i+1)%20 == 0 {
+			s.checkRateLimit(ctx)
+		}
+	}
+	return nil
+}
+
+// syncIssueReactions stores reactions on issues
+func (s *Syncer) syncIssueReactions(ctx context.Context, since time.Time, p *stepProgress) error {
+	issues, err := s.listIssues(ctx, since)
+	if err != nil {
+		return err
+	}
+
+	for i, issue := range issues {
+		if !p.pending(int64(issue.Number)) {
+			continue
+		}
+		s.syncReactions(ctx, IssueReactions(issue.Number), p)
+		p.done(ctx, int64(issue.Number), len(issues))
+
+		if (i+1)%20 == 0 {
+			s.checkRateLimit(ctx)
+		}
+	}
+	return nil
+}
+
+// syncComments stores issue/PR comments, attributed to their parent issue or PR
+func (s *Syncer) syncComments(ctx context.Context, since time.Time, p *stepProgress) error {
+	comments, err := s.listComments(ctx, since)
+	if err != nil {
+		return err
+	}
+	slog.Info("Sync: comments fetched", "count", len(comments))
+
+	// Issues and PRs updated since include every comment parent
+	// (commenting bumps updated_at); the issues API lists both
+	parentList, err := s.githubClient.GetIssuesUpdatedSince(ctx, s.owner, s.repo, since)
+	if err != nil {
+		return fmt.Errorf("failed to fetch comment parents: %w", err)
+	}
+	parents := make(map[int]*CommentParent, len(parentList))
+	for _, issue := range parentList {
+		parents[issue.Number] = &CommentParent{Title: issue.Title, IsPR: issue.PullRequest != nil}
+	}
+
+	// Older rows used a flat payload shape, not the Events API shape. Keyed
+	// ones are rewritten in place (see upsert); unkeyed ones are re-inserted.
+	commentTypes := []EventType{EventIssueComment}
+	if since.IsZero() && p.cp.LastKey == 0 {
+		deleted, err := s.store.DeleteUnkeyedFlatEvents(ctx, commentTypes)
+		if err != nil {
+			return fmt.Errorf("failed to delete old comment events: %w", err)
+		}
+		if deleted > 0 {
+			slog.Info("Sync: deleted old comment events for re-insert", "count", deleted)
+		}
+	}
+
+	for i := range comments {
+		comment := &comments[i]
+		if !p.pending(comment.ID) {
+			continue
+		}
+		p.upsert(ctx, IssueCommentCreatedEvent(comment, parents[issueNumberFromURL(comment.IssueURL)]), commentTypes)
+		p.done(ctx, comment.ID, len(comments))
+	}
+	return nil
+}
+
+// syncCommentReactions stores reactions on issue/PR comments
+func (s *Syncer) syncCommentReactions(ctx context.Context, since time.Time, p *stepProgress) error {
+	comments, err := s.listComments(ctx, since)
+	if err != nil {
+		return err
+	}
+
+	for i, comment := range comments {
+		if !p.pending(comment.ID) {
+			continue
+		}
+		s.syncReactions(ctx, CommentReactions(comment.ID), p)
+		p.done(ctx, comment.ID, len(comments))
+
+		if (i+1)%50 == 0 {
+			s.checkRateLimit(ctx)
+		}
+	}
+	return nil
+}
+
+// syncReactions stores a target's current reactions and retracts stored ones
+// GitHub no longer reports. A failed fetch is logged and skipped; a truncated
+// one is stored but not reconciled.
+func (s *Syncer) syncReactions(ctx context.Context, target ReactionTarget, p *stepProgress) {
+	var reactions []github.DetailedReaction
+	var err error
+	if target.Kind == "comment" {
+		reactions, err = s.githubClient.GetCommentReactions(ctx, s.owner, s.repo, target.CommentID)
+	} else {
+		reactions, err = s.githubClient.GetIssueReactions(ctx, s.owner, s.repo, target.Number)
+	}
+	truncated := errors.Is(err, github.ErrReactionsTruncated)
+	if err != nil && !truncated {
+		slog.Warn("Failed to fetch reactions", "target", target.String(), "error", err)
+		return
+	}
+
+	currentIDs := make([]int64, 0, len(reactions))
+	for _, reaction := range reactions {
+		p.insert(ctx, ReactionEvent(reaction, target))
+		currentIDs = append(currentIDs, reaction.ID)
+	}
+
+	if truncated {
+		slog.Warn("Reactions truncated, skipping retraction check", "target", target.String(), "fetched", len(reactions))
+		return
+	}
+
+	if _, err := s.store.RetractMissingReactions(ctx, target, currentIDs, time.Now()); err != nil {
+		slog.Warn("Failed to reconcile reactions", "target", target.String(), "error", err)
+	}
+}
+
+// syncStars stores stargazers starred since, keyed by user ID
+func (s *Syncer) syncStars(ctx context.Context, since time.Time, p *stepProgress) error {
+	stargazers, err := s.githubClient.GetStargazersWithTimestamps(ctx, s.owner, s.repo)
+	if err != nil {
+		return fmt.Errorf("failed to fetch stargazers: %w", err)
+	}
+	stargazers = slices.DeleteFunc(stargazers, func(st github.Stargazer) bool {
+		return st.StarredAt.Before(since)
+	})
+	sort.Slice(stargazers, func(i, j int) bool { return stargazers[i].User.ID < stargazers[j].User.ID })
+	slog.Info("Sync: stargazers fetched", "count", len(stargazers))
+
+	for i := range stargazers {
+		stargazer := &stargazers[i]
+		if !p.pending(stargazer.User.ID) {
+			continue
+		}
+		p.insert(ctx, StarEvent(stargazer))
+		p.done(ctx, stargazer.User.ID, len(stargazers))
+	}
+	return nil
+}
+
+// syncForks stores forks created since, keyed by fork repository ID
+func (s *Syncer) syncForks(ctx context.Context, since time.Time, p *stepProgress) error {
+	forks, err := s.githubClient.GetForks(ctx, s.owner, s.repo)
+	if err != nil {
+		return fmt.Errorf("failed to fetch forks: %w", err)
+	}
+	forks = slices.DeleteFunc(forks, func(fork github.Fork) bool {
+		return fork.CreatedAt.Before(since)
+	})
+	sort.Slice(forks, func(i, j int) bool { return forks[i].ID < forks[j].ID })
+	slog.Info("Sync: forks fetched", "count", len(forks))
+
+	for i := range forks {
+		fork := &forks[i]
+		if !p.pending(fork.ID) {
+			continue
+		}
+		p.insert(ctx, ForkEvent(fork))
+		p.done(ctx, fork.ID, len(forks))
+	}
+	return nil
+}
+
+// syncDiscussions stores discussion threads updated since, keyed by number
+func (s *Syncer) syncDiscussions(ctx context.Context, since time.Time, p *stepProgress) error {
+	if s.graphqlClient == nil {
+		slog.Warn("Sync: GraphQL client not configured, skipping discussions")
+		return nil
+	}
+
+	discussions, fetchErr := s.graphqlClient.FetchDiscussions(ctx, s.owner, s.repo, since)
+	sort.Slice(discussions, func(i, j int) bool { return discussions[i].Number < discussions[j].Number })
+	slog.Info("Sync: discussions fetched", "count", len(discussions))
+
+	claim, err := s.store.HasUnclaimedDiscussionEvents(ctx)
+	if err != nil {
+		slog.Warn("Failed to check for unclaimed discussion events", "error", err)
+		claim = true
+	}
+
+	for _, discussion := range discussions {
+		if !p.pending(int64(discussion.Number)) {
+			continue
+		}
+		for _, event := range DiscussionEvents(discussion) {
+			inserted, err := s.store.InsertDiscussionEvent(ctx, event, claim)
+			if err != nil {
+				slog.Warn("Failed to insert discussion event", "discussion", discussion.Number, "type", event.Type, "error", err)
+			} else if inserted {
+				p.inserted++
+			}
+		}
+		// A partial fetch isn't a prefix by number, so only checkpoint complete ones
+		if fetchErr == nil {
+			p.done(ctx, int64(discussion.Number), len(discussions))
+		}
+	}
+
+	if fetchErr != nil {
+		return fmt.Errorf("failed to fetch discussions (stored %d fetched before the error): %w", len(discussions), fetchErr)
+	}
+
+	rate := s.graphqlClient.RateLimit()
+	slog.Info("Sync: GraphQL cost",
+		"total_cost", rate.TotalCost,
+		"remaining", rate.Remaining,
+		"resets_at", rate.ResetAt,
+	)
+	return nil
+}
+
+// cleanup runs the post-sync dedup and normalization passes
+func (s *Syncer) cleanup(ctx context.Context) {
+	// Backfill + ingester can create star/fork duplicates
+	deduped, err := s.store.DeduplicateStarsForks(ctx)
+	if err != nil {
+		slog.Warn("Failed to deduplicate stars/forks", "error", err)
+	} else if deduped > 0 {
+		slog.Info("Sync: removed duplicate star/fork events", "count", deduped)
+	}
+
+	// Normalize GraphQL uppercase reaction types (THUMBS_UP → +1, etc.)
+	normalized, err := s.store.NormalizeReactionTypes(ctx)
+	if err != nil {
+		slog.Warn("Failed to normalize reaction types", "error", err)
+	} else if normalized > 0 {
+		slog.Info("Sync: normalized reaction types", "count", normalized)
+	}
+}
+
+// checkRateLimit sleeps until the REST rate limit resets when it runs low
+func (s *Syncer) checkRateLimit(ctx context.Context) {
+	rateLimit, err := s.githubClient.GetRateLimit(ctx)
+	if err != nil {
+		slog.Warn("Failed to check rate limit", "error", err)
+		return
+	}
+
+	slog.Info("Sync: rate limit",
+		"remaining", rateLimit.Remaining,
+		"limit", rateLimit.Limit,
+		"resets_at", rateLimit.Reset.Format("15:04:05"),
+	)
+
+	if rateLimit.Remaining < 100 {
+		sleepDuration := time.Until(rateLimit.Reset).Round(time.Second) + 5*time.Second // 5s buffer
+		if sleepDuration > 0 {
+			slog.Warn("Rate limit low, sleeping until reset", "sleep", sleepDuration)
+			select {
+			case <-time.After(sleepDuration):
+			case <-ctx.Done():
+			}
+		}
+	}
+}
+
+// CatchUp reconstructs events between since and until from the REST API:
+// PRs and issues opened, closed, merged or reopened, and issue/PR comments.
+// Unlike a full sync it records individual transitions (via the issue events
+// API) rather than one state event per PR. Reviews, pushes, stars and other
+// Events API-only activity are not recoverable this way. The prs and issues
+// tables are brought up to date along the way.
+// Returns the number of newly inserted events.
+func (s *Syncer) CatchUp(ctx context.Context, since, until time.Time) (int, error) {
+	inRange := func(t time.Time) bool {
+		return !t.Before(since) && !t.After(until)
+	}
+
+	prs, err := s.githubClient.GetPRsUpdatedSince(ctx, s.owner, s.repo, since)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch PRs: %w", err)
+	}
+	issues, err := s.githubClient.GetIssuesUpdatedSince(ctx, s.owner, s.repo, since)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch issues: %w", err)
+	}
+	issueEvents, err := s.githubClient.GetIssueEventsSince(ctx, s.owner, s.repo, since)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch issue events: %w", err)
+	}
+	comments, err := s.githubClient.GetCommentsSince(ctx, s.owner, s.repo, since)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch comments: %w", err)
+	}
+
+	var events []*Event
+
+	for _, pr := range prs {
+		if err := s.store.UpsertPR(ctx, PullRequestFromREST(pr)); err != nil {
+			return 0, err
+		}
+		event := PROpenedEvent(pr)
+		if inRange(event.OccurredAt) {
+			events = append(events, event)
+		}
+	}
+
+	// Issues updated since the gap start include every comment parent
+	// (commenting bumps updated_at), so comments can be attributed
+	parents := make(map[int]*CommentParent, len(issues))
+	for i := range issues {
+		issue := &issues[i]
+		parents[issue.Number] = &CommentParent{Title: issue.Title, IsPR: issue.PullRequest != nil}
+		if issue.PullRequest != nil {
+			continue
+		}
+		if err := s.store.UpsertIssue(ctx, IssueFromREST(issue)); err != nil {
+			return 0, err
+		}
+		if inRange(issue.CreatedAt) {
+			events = append(events, IssueOpenedEvent(issue))
+		}
+	}
+
+	merged := make(map[int]bool)
+	for _, ie := range issueEvents {
+		if ie.Event == "merged" {
+			merged[ie.Issue.Number] = true
+		}
+	}
+	for i := range issueEvents {
+		ie := &issueEvents[i]
+		if !inRange(ie.CreatedAt) {
+			continue
+		}
+		if event := IssueLifecycleEvent(ie, merged[ie.Issue.Number]); event != nil {
+			events = append(events, event)
+		}
+	}
+
+	for i := range comments {
+		comment := &comments[i]
+		if !inRange(comment.CreatedAt) {
+			continue // Edited during the gap, created outside it
+		}
+		events = append(events, IssueCommentCreatedEvent(comment, parents[issueNumberFromURL(comment.IssueURL)]))
+	}
+
+	recovered := 0
+	for _, event := range events {
+		if err := s.store.Insert(ctx, event); err != nil {
+			return recovered, err
+		}
+		if event.ID != "" {
+			recovered++
+		}
+	}
+	return recovered, nil
+}
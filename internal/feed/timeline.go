@@ -0,0 +1,203 @@
+package feed
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Timeline bucket sizes
+const (
+	BucketEvent = "event" // One point per vote change
+	BucketHour  = "hour"
+	BucketDay   = "day"
+)
+
+// VoteTimeline is the evolution of a PR's vote tally
+type VoteTimeline struct {
+	PRNumber int               `json:"prNumber"`
+	Bucket   string            `json:"bucket"`
+	Points   []*TimelinePoint  `json:"points"`
+	Markers  []*TimelineMarker `json:"markers"`
+}
+
+// TimelinePoint is the cumulative tally at the end of a bucket (or after a
+// single change for BucketEvent). Buckets without changes are omitted; the
+// tally carries over from the previous point.
+type TimelinePoint struct {
+	At        time.Time `json:"at"` // Bucket start, or the change time for BucketEvent
+	Upvotes   int       `json:"upvotes"`
+	Downvotes int       `json:"downvotes"`
+	Net       int       `json:"net"`
+}
+
+// TimelineMarker is a PR lifecycle event shown alongside the tally
+type TimelineMarker struct {
+	At         time.Time `json:"at"`
+	Type       EventType `json:"type"`
+	EventID    string    `json:"eventId"`
+	GitHubUser string    `json:"githubUser"`
+}
+
+// timelineMarkerTypes are the lifecycle events reported as markers
+var timelineMarkerTypes = []EventType{
+	EventPROpened, EventPRSynchronized, EventPRClosed, EventPRReopened, EventPRMerged,
+}
+
+// timelineVote is one vote reaction row, including retracted ones
+type timelineVote struct {
+	User        string // Identity key: account ID, or login for rows without one
+	Choice      int8
+	OccurredAt  time.Time
+	RetractedAt *time.Time
+}
+
+// GetPRVoteTimeline returns how a PR's tally evolved. At any point in time a
+// user's vote is their latest vote cast by then and not yet retracted, so the
+// final point matches GetPRVotes.
+func (s *Store) GetPRVoteTimeline(ctx context.Context, prNumber int, bucket string) (*VoteTimeline, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT `+voterKey("")+`,
+			choice, occurred_at, retracted_at
+		FROM events
+		WHERE type = 'reaction' AND pr_number = $1 AND choice IS NOT NULL AND comment_id IS NULL
+		ORDER BY occurred_at ASC
+	`, prNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get PR vote history: %w", err)
+	}
+	defer rows.Close()
+
+	var votes []timelineVote
+	for rows.Next() {
+		var v timelineVote
+		if err := rows.Scan(&v.User, &v.Choice, &v.OccurredAt, &v.RetractedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan vote: %w", err)
+		}
+		votes = append(votes, v)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read PR vote history: %w", err)
+	}
+
+	markerRows, err := s.pool.Query(ctx, `
+		SELECT id, type, github_user, occurred_at
+		FROM events
+		WHERE pr_number = $1 AND type = ANY($2)
+		ORDER BY occurred_at ASC
+	`, prNumber, timelineMarkerTypes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get PR lifecycle events: %w", err)
+	}
+	defer markerRows.Close()
+
+	markers := []*TimelineMarker{}
+	for markerRows.Next() {
+		m := &TimelineMarker{}
+		if err := markerRows.Scan(&m.EventID, &m.Type, &m.GitHubUser, &m.At); err != nil {
+			return nil, fmt.Errorf("failed to scan lifecycle event: %w", err)
+		}
+		markers = append(markers, m)
+	}
+
+	return &VoteTimeline{
+		PRNumber: prNumber,
+		Bucket:   bucket,
+		Points:   buildVoteTimeline(votes, bucket),
+		Markers:  markers,
+	}, nil
+}
+
+// buildVoteTimeline replays votes and retractions in time order, recomputing
+// the effective vote of each affected user at every change
+func buildVoteTimeline(votes []timelineVote, bucket string) []*TimelinePoint {
+	type change struct {
+		at   time.Time
+		user string
+	}
+
+	byUser := make(map[string][]timelineVote)
+	var changes []change
+	for _, v := range votes {
+		byUser[v.User] = append(byUser[v.User], v)
+		changes = append(changes, change{v.OccurredAt, v.User})
+		if v.RetractedAt != nil {
+			changes = append(changes, change{*v.RetractedAt, v.User})
+		}
+	}
+	sort.SliceStable(changes, func(i, j int) bool { return changes[i].at.Before(changes[j].at) })
+
+	// effective returns the user's vote as of t (0 if none)
+	effective := func(user string, t time.Time) int8 {
+		var choice int8
+		var latest time.Time
+		for _, v := range byUser[user] {
+			if v.OccurredAt.After(t) || (v.RetractedAt != nil && !v.RetractedAt.After(t)) {
+				continue
+			}
+			if choice == 0 || !v.OccurredAt.Before(latest) {
+				choice, latest = v.Choice, v.OccurredAt
+			}
+		}
+		return choice
+	}
+
+	current := make(map[string]int8)
+	upvotes, downvotes := 0, 0
+	points := []*TimelinePoint{}
+
+	for i := 0; i < len(changes); {
+		at := changes[i].at
+
+		// Apply every change at this instant before emitting a point
+		for ; i < len(changes) && changes[i].at.Equal(at); i++ {
+			user := changes[i].user
+			next := effective(user, at)
+			switch current[user] {
+			case 1:
+				upvotes--
+			case -1:
+				downvotes--
+			}
+			switch next {
+			case 1:
+				upvotes++
+			case -1:
+				downvotes++
+			}
+			current[user] = next
+		}
+
+		point := &TimelinePoint{
+			At:        bucketStart(at, bucket),
+			Upvotes:   upvotes,
+			Downvotes: downvotes,
+			Net:       upvotes - downvotes,
+		}
+		if n := len(points); n > 0 {
+			last := points[n-1]
+			if last.At.Equal(point.At) {
+				points[n-1] = point // Same bucket: keep the end-of-bucket tally
+				continue
+			}
+			if last.Upvotes == point.Upvotes && last.Downvotes == point.Downvotes {
+				continue // e.g. a user re-adding the same vote
+			}
+		}
+		points = append(points, point)
+	}
+	return points
+}
+
+// bucketStart truncates t to the start of its bucket (UTC)
+func bucketStart(t time.Time, bucket string) time.Time {
+	switch bucket {
+	case BucketHour:
+		return t.UTC().Truncate(time.Hour)
+	case BucketDay:
+		return t.UTC().Truncate(24 * time.Hour)
+	default:
+		return t
+	}
+}
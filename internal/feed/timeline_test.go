@@ -0,0 +1,78 @@
+package feed
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBuildVoteTimeline(t *testing.T) {
+	at := func(s string) time.Time {
+		parsed, _ := time.Parse(time.RFC3339, s)
+		return parsed
+	}
+	ptr := func(t time.Time) *time.Time { return &t }
+
+	votes := []timelineVote{
+		{User: "alice", Choice: 1, OccurredAt: at("2026-03-01T10:00:00Z")},
+		{User: "bob", Choice: 1, OccurredAt: at("2026-03-01T10:30:00Z")},
+		// alice switches to a downvote: last vote wins
+		{User: "alice", Choice: -1, OccurredAt: at("2026-03-01T12:00:00Z"), RetractedAt: ptr(at("2026-03-02T09:00:00Z"))},
+		{User: "carol", Choice: -1, OccurredAt: at("2026-03-02T08:00:00Z")},
+	}
+
+	tests := []struct {
+		name   string
+		bucket string
+		want   []TimelinePoint // At, Upvotes, Downvotes
+	}{
+		{
+			name:   "per event",
+			bucket: BucketEvent,
+			want: []TimelinePoint{
+				{At: at("2026-03-01T10:00:00Z"), Upvotes: 1},
+				{At: at("2026-03-01T10:30:00Z"), Upvotes: 2},
+				{At: at("2026-03-01T12:00:00Z"), Upvotes: 1, Downvotes: 1},
+				{At: at("2026-03-02T08:00:00Z"), Upvotes: 1, Downvotes: 2},
+				// Retracting the downvote restores alice's earlier upvote
+				{At: at("2026-03-02T09:00:00Z"), Upvotes: 2, Downvotes: 1},
+			},
+		},
+		{
+			name:   "hourly",
+			bucket: BucketHour,
+			want: []TimelinePoint{
+				{At: at("2026-03-01T10:00:00Z"), Upvotes: 2},
+				{At: at("2026-03-01T12:00:00Z"), Upvotes: 1, Downvotes: 1},
+				{At: at("2026-03-02T08:00:00Z"), Upvotes: 1, Downvotes: 2},
+				{At: at("2026-03-02T09:00:00Z"), Upvotes: 2, Downvotes: 1},
+			},
+		},
+		{
+			name:   "daily",
+			bucket: BucketDay,
+			want: []TimelinePoint{
+				{At: at("2026-03-01T00:00:00Z"), Upvotes: 1, Downvotes: 1},
+				{At: at("2026-03-02T00:00:00Z"), Upvotes: 2, Downvotes: 1},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := buildVoteTimeline(votes, tt.bucket)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d points, want %d", len(got), len(tt.want))
+			}
+			for i, want := range tt.want {
+				p := got[i]
+				if !p.At.Equal(want.At) || p.Upvotes != want.Upvotes || p.Downvotes != want.Downvotes {
+					t.Errorf("point %d = %s +%d -%d, want %s +%d -%d",
+						i, p.At, p.Upvotes, p.Downvotes, want.At, want.Upvotes, want.Downvotes)
+				}
+				if p.Net != p.Upvotes-p.Downvotes {
+					t.Errorf("point %d Net = %d, want %d", i, p.Net, p.Upvotes-p.Downvotes)
+				}
+			}
+		})
+	}
+}
@@ -1,6 +1,9 @@
 package feed
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 // VoterSummary represents aggregated voting statistics for a user
 // Used for Sybil resistance research and behavioral analysis
@@ -14,4 +17,47 @@ type VoterSummary struct {
 	LastVote     time.Time `json:"lastVote"`
 	PRsVotedOn   []int     `json:"prsVotedOn"`
 	UniquePRs    int       `json:"uniquePrs"`
+
+	// Times the voter changed direction on a PR: consecutive vote reactions
+	// with different choices, counting retracted ones (see GetPRVoteChanges)
+	Flips int `json:"flips"`
+
+	// GitHub account profile, nil until the user enricher has fetched it
+	Profile *UserProfile `json:"profile,omitempty"`
+
+	// Every login the account has used, oldest first (GetVoter only)
+	Aliases []string `json:"aliases,omitempty"`
+}
+
+// VoterFilters narrows GetVoters by account profile. Voters not yet enriched
+// are excluded whenever a filter is set.
+type VoterFilters struct {
+	MinAccountAgeDays *int   // Accounts at least this old
+	MaxAccountAgeDays *int   // Accounts at most this old (e.g. to spot new accounts)
+	Type              string // User or Bot; empty for any
+}
+
+// clause returns the WHERE conditions (on github_users alias u) and their args
+func (f *VoterFilters) clause(argPos int) (string, []interface{}) {
+	if f == nil {
+		return "", nil
+	}
+	var clause string
+	var args []interface{}
+
+	if f.MinAccountAgeDays != nil {
+		clause += fmt.Sprintf(" AND u.account_created_at <= NOW() - make_interval(days => $%d)", argPos)
+		args = append(args, *f.MinAccountAgeDays)
+		argPos++
+	}
+	if f.MaxAccountAgeDays != nil {
+		clause += fmt.Sprintf(" AND u.account_created_at >= NOW() - make_interval(days => $%d)", argPos)
+		args = append(args, *f.MaxAccountAgeDays)
+		argPos++
+	}
+	if f.Type != "" {
+		clause += fmt.Sprintf(" AND u.type = $%d", argPos)
+		args = append(args, f.Type)
+	}
+	return clause, args
 }
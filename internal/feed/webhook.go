@@ -0,0 +1,184 @@
+package feed
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/skridlevsky/openchaos-feed/internal/github"
+)
+
+// webhookEventTypes maps X-GitHub-Event names to the Events API type names
+// understood by parseGitHubEvent. Webhook payloads share the Events API payload
+// shape plus top-level repository/sender metadata.
+//
+// create, delete, gollum, member and public are left to the Events API poller:
+// their only identity there is the Events API event ID, which webhooks don't
+// carry, so accepting both would store duplicates.
+var webhookEventTypes = map[string]string{
+	"pull_request":                "PullRequestEvent",
+	"pull_request_review":         "PullRequestReviewEvent",
+	"pull_request_review_comment": "PullRequestReviewCommentEvent",
+	"issues":                      "IssuesEvent",
+	"issue_comment":               "IssueCommentEvent",
+	"discussion":                  "DiscussionEvent",
+	"star":                        "WatchEvent",
+	"watch":                       "WatchEvent",
+	"fork":                        "ForkEvent",
+	"push":                        "PushEvent",
+	"release":                     "ReleaseEvent",
+	"commit_comment":              "CommitCommentEvent",
+}
+
+// webhookMetadataKeys are top-level webhook fields absent from Events API payloads.
+// Stripped before storage so both sources produce the same payload shape.
+var webhookMetadataKeys = []string{"repository", "sender", "organization", "installation", "enterprise"}
+
+// SupportsWebhookEvent reports whether a webhook event name is ingested
+func SupportsWebhookEvent(name string) bool {
+	_, ok := webhookEventTypes[name]
+	return ok
+}
+
+// IngestWebhook parses a webhook delivery through the same path as the Events
+// API poller and stores the resulting events. Polling keeps running as a
+// fallback; github_id/source_key dedup makes whichever arrives second a no-op.
+// Returns the number of newly inserted events.
+func (ing *Ingester) IngestWebhook(ctx context.Context, eventName string, body []byte) (int, error) {
+	eventType, ok := webhookEventTypes[eventName]
+	if !ok {
+		return 0, fmt.Errorf("unsupported webhook event: %s", eventName)
+	}
+
+	ing.statusMu.Lock()
+	ing.webhooksLastDelivery = time.Now()
+	ing.webhooksStatus = "running"
+	ing.statusMu.Unlock()
+
+	raw, err := webhookToRawEvent(eventType, body)
+	if err != nil {
+		ing.setWebhookError(err)
+		return 0, err
+	}
+	if raw == nil {
+		return 0, nil // Action with no feed equivalent (e.g. unstar)
+	}
+
+	feedEvents, err := ing.parseGitHubEvent(ctx, raw)
+	if err != nil {
+		ing.setWebhookError(err)
+		return 0, err
+	}
+	ing.trackEntity(ctx, raw)
+
+	inserted := 0
+	for _, feedEvent := range feedEvents {
+		if err := ing.store.Insert(ctx, feedEvent); err != nil {
+			ing.setWebhookError(err)
+			return inserted, err
+		}
+		if feedEvent.ID != "" {
+			inserted++
+		}
+	}
+
+	ing.saveOpenPRs(ctx)
+
+	slog.Info("Webhook processed",
+		"event", eventName,
+		"new_events", inserted,
+	)
+	return inserted, nil
+}
+
+// setWebhookError records a webhook failure for the health endpoint
+func (ing *Ingester) setWebhookError(err error) {
+	ing.statusMu.Lock()
+	ing.webhooksStatus = "error: " + err.Error()
+	ing.statusMu.Unlock()
+}
+
+// webhookTimestamps are the payload timestamps a delivery's time is taken
+// from, most specific first
+var webhookTimestamps = []struct{ object, field string }{
+	{"review", "submitted_at"},
+	{"comment", "created_at"},
+	{"pull_request", "updated_at"},
+	{"issue", "updated_at"},
+}
+
+// webhookOccurredAt returns the first of webhookTimestamps present in the
+// payload, or now if none is
+func webhookOccurredAt(payload map[string]json.RawMessage) time.Time {
+	for _, ts := range webhookTimestamps {
+		raw, ok := payload[ts.object]
+		if !ok {
+			continue
+		}
+		var object map[string]json.RawMessage
+		if json.Unmarshal(raw, &object) != nil {
+			continue
+		}
+		var at *time.Time
+		if json.Unmarshal(object[ts.field], &at) == nil && at != nil && !at.IsZero() {
+			return at.UTC()
+		}
+	}
+	return time.Now().UTC()
+}
+
+// webhookToRawEvent reshapes a webhook delivery into a RawGitHubEvent.
+// Returns nil if the delivery's action has no feed equivalent.
+func webhookToRawEvent(eventType string, body []byte) (*github.RawGitHubEvent, error) {
+	var payload map[string]json.RawMessage
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("failed to parse webhook payload: %w", err)
+	}
+
+	var sender github.EventActor
+	if raw, ok := payload["sender"]; ok {
+		if err := json.Unmarshal(raw, &sender); err != nil {
+			return nil, fmt.Errorf("failed to parse webhook sender: %w", err)
+		}
+	}
+
+	// Webhooks don't carry an event timestamp; use the subject's own time, so
+	// late redeliveries keep their place
+	occurredAt := webhookOccurredAt(payload)
+
+	if eventType == "WatchEvent" {
+		var action string
+		json.Unmarshal(payload["action"], &action)
+		switch action {
+		case "started": // watch event
+		case "created": // star event
+			var starred struct {
+				StarredAt *time.Time `json:"starred_at"`
+			}
+			if json.Unmarshal(body, &starred) == nil && starred.StarredAt != nil {
+				occurredAt = *starred.StarredAt
+			}
+		default:
+			return nil, nil // Unstarring has no feed event
+		}
+		payload = map[string]json.RawMessage{"action": json.RawMessage(`"started"`)}
+	}
+
+	for _, key := range webhookMetadataKeys {
+		delete(payload, key)
+	}
+	trimmed, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	return &github.RawGitHubEvent{
+		Type:      eventType,
+		Actor:     sender,
+		Payload:   trimmed,
+		Public:    true,
+		CreatedAt: occurredAt,
+	}, nil
+}
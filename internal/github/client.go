@@ -3,6 +3,7 @@ package github
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -128,6 +129,13 @@ type GitHubPR struct {
 	CreatedAt string `json:"created_at"`
 	UpdatedAt string `json:"updated_at"`
 	Merged    bool   `json:"merged"`
+	MergedAt  *time.Time `json:"merged_at"` // Set in list responses, unlike Merged
+	ClosedAt  *time.Time `json:"closed_at"`
+	Draft     bool       `json:"draft"`
+	Labels    []Label    `json:"labels"`
+	Head      struct {
+		SHA string `json:"sha"`
+	} `json:"head"`
 }
 
 // GetOpenPRs fetches all open PRs for a repository
@@ -485,13 +493,23 @@ func (c *Client) GetAllPRs(ctx context.Context, owner, repo string) ([]*GitHubPR
 
 // GetAllIssues fetches all issues (open and closed) with pagination
 func (c *Client) GetAllIssues(ctx context.Context, owner, repo string) ([]GitHubIssue, error) {
+	return c.getIssues(ctx, owner, repo, "all")
+}
+
+// GetOpenIssues fetches all open issues with pagination
+func (c *Client) GetOpenIssues(ctx context.Context, owner, repo string) ([]GitHubIssue, error) {
+	return c.getIssues(ctx, owner, repo, "open")
+}
+
+// getIssues fetches issues in the given state, excluding pull requests
+func (c *Client) getIssues(ctx context.Context, owner, repo, state string) ([]GitHubIssue, error) {
 	allIssues := []GitHubIssue{}
 	page := 1
 	perPage := 100
 
 	for {
-		url := fmt.Sprintf("https://api.github.com/repos/%s/%s/issues?state=all&per_page=%d&page=%d",
-			owner, repo, perPage, page)
+		url := fmt.Sprintf("https://api.github.com/repos/%s/%s/issues?state=%s&per_page=%d&page=%d",
+			owner, repo, state, perPage, page)
 
 		resp, err := c.doRequest(ctx, "GET", url)
 		if err != nil {
@@ -543,11 +561,168 @@ type GitHubIssue struct {
 	} `json:"user"`
 	CreatedAt   time.Time  `json:"created_at"`
 	UpdatedAt   time.Time  `json:"updated_at"`
+	ClosedAt    *time.Time `json:"closed_at"`
+	Labels      []Label    `json:"labels"`
 	PullRequest *struct{}  `json:"pull_request,omitempty"` // Present if this is actually a PR
 }
 
-// GetAllComments fetches all issue comments with pagination
-func (c *Client) GetAllComments(ctx context.Context, owner, repo string) ([]GitHubComment, error) {
+// GetPRsUpdatedSince fetches PRs (any state) updated at or after since.
+// The pulls API has no since filter, so this walks PRs newest-updated first
+// and stops at the first older one.
+func (c *Client) GetPRsUpdatedSince(ctx context.Context, owner, repo string, since time.Time) ([]*GitHubPR, error) {
+	allPRs := []*GitHubPR{}
+	page := 1
+	perPage := 100
+
+	for {
+		url := fmt.Sprintf("https://api.github.com/repos/%s/%s/pulls?state=all&sort=updated&direction=desc&per_page=%d&page=%d",
+			owner, repo, perPage, page)
+
+		resp, err := c.doRequest(ctx, "GET", url)
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, readErrorAndClose(resp)
+		}
+
+		var prs []GitHubPR
+		if err := readAndClose(resp, &prs); err != nil {
+			return nil, fmt.Errorf("failed to decode response: %w", err)
+		}
+
+		for i := range prs {
+			updatedAt, err := time.Parse(time.RFC3339, prs[i].UpdatedAt)
+			if err == nil && updatedAt.Before(since) {
+				return allPRs, nil
+			}
+			allPRs = append(allPRs, &prs[i])
+		}
+
+		if len(prs) < perPage {
+			break
+		}
+
+		page++
+	}
+
+	return allPRs, nil
+}
+
+// GetIssuesUpdatedSince fetches issues (any state) updated at or after since;
+// a zero since fetches all. Unlike GetAllIssues, pull requests are kept;
+// check PullRequest to tell them apart.
+func (c *Client) GetIssuesUpdatedSince(ctx context.Context, owner, repo string, since time.Time) ([]GitHubIssue, error) {
+	allIssues := []GitHubIssue{}
+	page := 1
+	perPage := 100
+
+	for {
+		url := fmt.Sprintf("https://api.github.com/repos/%s/%s/issues?state=all&per_page=%d&page=%d",
+			owner, repo, perPage, page)
+		if !since.IsZero() {
+			url += "&since=" + since.UTC().Format(time.RFC3339)
+		}
+
+		resp, err := c.doRequest(ctx, "GET", url)
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, readErrorAndClose(resp)
+		}
+
+		var issues []GitHubIssue
+		if err := readAndClose(resp, &issues); err != nil {
+			return nil, fmt.Errorf("failed to decode response: %w", err)
+		}
+
+		allIssues = append(allIssues, issues...)
+
+		if len(issues) < perPage {
+			break
+		}
+
+		page++
+	}
+
+	return allIssues, nil
+}
+
+// IssueEvent is an entry from the repository issue events API (closed,
+// reopened, merged, ...). Covers both issues and pull requests.
+type IssueEvent struct {
+	ID    int64  `json:"id"`
+	Event string `json:"event"`
+	Actor struct {
+		Login string `json:"login"`
+		ID    int64  `json:"id"`
+	} `json:"actor"`
+	CreatedAt time.Time       `json:"created_at"`
+	Issue     IssueEventIssue `json:"issue"`
+}
+
+// IssueEventIssue is the issue or PR an IssueEvent belongs to
+type IssueEventIssue struct {
+	ID      int64  `json:"id"`
+	Number  int    `json:"number"`
+	Title   string `json:"title"`
+	State   string `json:"state"`
+	HTMLURL string `json:"html_url"`
+	User    struct {
+		Login string `json:"login"`
+		ID    int64  `json:"id"`
+	} `json:"user"`
+	PullRequest *struct{} `json:"pull_request,omitempty"` // Present if this is a PR
+}
+
+// GetIssueEventsSince fetches repository issue events created at or after since.
+// The API returns newest first; pagination stops at the first older event.
+func (c *Client) GetIssueEventsSince(ctx context.Context, owner, repo string, since time.Time) ([]IssueEvent, error) {
+	allEvents := []IssueEvent{}
+	page := 1
+	perPage := 100
+
+	for {
+		url := fmt.Sprintf("https://api.github.com/repos/%s/%s/issues/events?per_page=%d&page=%d",
+			owner, repo, perPage, page)
+
+		resp, err := c.doRequest(ctx, "GET", url)
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, readErrorAndClose(resp)
+		}
+
+		var events []IssueEvent
+		if err := readAndClose(resp, &events); err != nil {
+			return nil, fmt.Errorf("failed to decode response: %w", err)
+		}
+
+		for _, event := range events {
+			if event.CreatedAt.Before(since) {
+				return allEvents, nil
+			}
+			allEvents = append(allEvents, event)
+		}
+
+		if len(events) < perPage {
+			break
+		}
+
+		page++
+	}
+
+	return allEvents, nil
+}
+
+// GetCommentsSince fetches issue and PR comments updated at or after since;
+// a zero since fetches all.
+func (c *Client) GetCommentsSince(ctx context.Context, owner, repo string, since time.Time) ([]GitHubComment, error) {
 	allComments := []GitHubComment{}
 	page := 1
 	perPage := 100
@@ -555,6 +730,9 @@ func (c *Client) GetAllComments(ctx context.Context, owner, repo string) ([]GitH
 	for {
 		url := fmt.Sprintf("https://api.github.com/repos/%s/%s/issues/comments?per_page=%d&page=%d",
 			owner, repo, perPage, page)
+		if !since.IsZero() {
+			url += "&since=" + since.UTC().Format(time.RFC3339)
+		}
 
 		resp, err := c.doRequest(ctx, "GET", url)
 		if err != nil {
@@ -570,10 +748,6 @@ func (c *Client) GetAllComments(ctx context.Context, owner, repo string) ([]GitH
 			return nil, fmt.Errorf("failed to decode response: %w", err)
 		}
 
-		if len(comments) == 0 {
-			break
-		}
-
 		allComments = append(allComments, comments...)
 
 		if len(comments) < perPage {
@@ -606,13 +780,25 @@ func (c *Client) GetCommentReactions(ctx context.Context, owner, repo string, co
 	return c.fetchAllReactions(ctx, fmt.Sprintf("https://api.github.com/repos/%s/%s/issues/comments/%d/reactions?per_page=100", owner, repo, commentID))
 }
 
+// maxReactionPages caps fetchAllReactions: 50 pages = 5,000 reactions
+const maxReactionPages = 50
+
+// ErrReactionsTruncated is returned (with the reactions fetched so far) when a
+// target has more reactions than maxReactionPages can hold. The result is not
+// the complete set, so it must not be used to detect removed reactions.
+var ErrReactionsTruncated = errors.New("reactions truncated at page cap")
+
 // fetchAllReactions paginates through all reaction pages for a given URL.
 // Closes response bodies immediately (not deferred) to prevent connection leaks.
 func (c *Client) fetchAllReactions(ctx context.Context, firstURL string) ([]DetailedReaction, error) {
 	var allReactions []DetailedReaction
 	url := firstURL
 
-	for page := 1; page <= 50; page++ { // Safety cap: 50 pages = 5,000 reactions
+	for page := 1; ; page++ {
+		if page > maxReactionPages {
+			return allReactions, ErrReactionsTruncated
+		}
+
 		resp, err := c.doRequest(ctx, "GET", url)
 		if err != nil {
 			return allReactions, err // Return partial results
@@ -812,3 +998,42 @@ type Fork struct {
 	} `json:"owner"`
 	CreatedAt time.Time `json:"created_at"`
 }
+
+// User is a GitHub account profile from the users API
+type User struct {
+	ID          int64     `json:"id"`
+	Login       string    `json:"login"`
+	Type        string    `json:"type"` // User, Bot or Organization
+	Followers   int       `json:"followers"`
+	PublicRepos int       `json:"public_repos"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// GetUserByID fetches an account's profile by numeric ID, which survives
+// renames. Returns nil if the account no longer exists. The response headers
+// carry the rate limit (see GetRateLimitFromHeaders).
+func (c *Client) GetUserByID(ctx context.Context, id int64) (*User, http.Header, error) {
+	url := fmt.Sprintf("https://api.github.com/user/%d", id)
+
+	resp, err := c.doRequest(ctx, "GET", url)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, resp.Header, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, resp.Header, fmt.Errorf("github API error %d: %s", resp.StatusCode, string(body))
+	}
+
+	var user User
+	if err := json.NewDecoder(resp.Body).Decode(&user); err != nil {
+		return nil, resp.Header, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &user, resp.Header, nil
+}
@@ -35,9 +35,20 @@ type EventRepo struct {
 
 // Event Payload Types - Each event type has a specific payload structure
 
+// EditChanges holds the previous values sent with an "edited" action.
+// A field is nil when it didn't change.
+type EditChanges struct {
+	Title *struct {
+		From string `json:"from"`
+	} `json:"title"`
+	Body *struct {
+		From string `json:"from"`
+	} `json:"body"`
+}
+
 // PullRequestEventPayload for PullRequestEvent
 type PullRequestEventPayload struct {
-	Action      string `json:"action"` // opened, closed, reopened, edited, synchronize
+	Action      string `json:"action"` // opened, closed, reopened, edited, synchronize, labeled, assigned, review_requested, converted_to_draft, ready_for_review
 	Number      int    `json:"number"`
 	PullRequest struct {
 		ID        int64  `json:"id"`
@@ -53,7 +64,35 @@ type PullRequestEventPayload struct {
 		UpdatedAt time.Time `json:"updated_at"`
 		Merged    bool      `json:"merged"`
 		MergedAt  *time.Time `json:"merged_at"`
+		ClosedAt  *time.Time `json:"closed_at"`
+		Draft     bool       `json:"draft"`
+		Labels    []Label    `json:"labels"`
+		Head      struct {
+			SHA string `json:"sha"`
+		} `json:"head"`
 	} `json:"pull_request"`
+	Changes           EditChanges  `json:"changes"`            // Set for edited
+	Label             *Label  `json:"label"`              // Set for labeled
+	Assignee          *EventUser   `json:"assignee"`           // Set for assigned
+	RequestedReviewer *EventUser   `json:"requested_reviewer"` // Set for review_requested (user)
+	RequestedTeam     *EventTeam   `json:"requested_team"`     // Set for review_requested (team)
+}
+
+// Label is a PR/issue label
+type Label struct {
+	Name string `json:"name"`
+}
+
+// EventUser is a user referenced by an action (assignee, requested reviewer)
+type EventUser struct {
+	ID    int64  `json:"id"`
+	Login string `json:"login"`
+}
+
+// EventTeam is a team referenced by an action (requested reviewer team)
+type EventTeam struct {
+	ID   int64  `json:"id"`
+	Slug string `json:"slug"`
 }
 
 // IssueCommentEventPayload for IssueCommentEvent
@@ -114,7 +153,7 @@ type ForkEventPayload struct {
 
 // IssuesEventPayload for IssuesEvent
 type IssuesEventPayload struct {
-	Action string `json:"action"` // opened, closed, reopened, edited
+	Action string `json:"action"` // opened, closed, reopened, edited, labeled, locked, transferred
 	Issue  struct {
 		ID        int64     `json:"id"`
 		Number    int       `json:"number"`
@@ -126,8 +165,12 @@ type IssuesEventPayload struct {
 		} `json:"user"`
 		Body      string    `json:"body"`
 		CreatedAt time.Time `json:"created_at"`
-		UpdatedAt time.Time `json:"updated_at"`
+		UpdatedAt time.Time  `json:"updated_at"`
+		ClosedAt  *time.Time `json:"closed_at"`
+		Labels    []Label    `json:"labels"`
 	} `json:"issue"`
+	Changes EditChanges `json:"changes"` // Set for edited
+	Label   *Label `json:"label"`   // Set for labeled
 }
 
 // PullRequestReviewEventPayload for PullRequestReviewEvent
@@ -229,9 +272,10 @@ type CommitCommentEventPayload struct {
 
 // DiscussionEventPayload for DiscussionEvent
 type DiscussionEventPayload struct {
-	Action     string `json:"action"` // created, edited, deleted, etc.
+	Action     string `json:"action"` // created, answered, unanswered, closed, locked, category_changed, ...
 	Discussion struct {
 		ID        int64     `json:"id"`
+		NodeID    string    `json:"node_id"`
 		Number    int       `json:"number"`
 		Title     string    `json:"title"`
 		User      struct {
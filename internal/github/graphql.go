@@ -7,13 +7,30 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"sync"
 	"time"
 )
 
+// minGraphQLRemaining is the point budget kept in reserve. Queries are refused
+// below it until the window resets, so pagination stops with partial results
+// instead of exhausting the token shared with the REST pollers.
+const minGraphQLRemaining = 100
+
 // GraphQLClient handles GitHub GraphQL API requests
 type GraphQLClient struct {
 	token      string
 	httpClient *http.Client
+
+	rateMu sync.Mutex
+	rate   GraphQLRateLimit
+}
+
+// GraphQLRateLimit tracks GraphQL point usage reported by the rateLimit field
+type GraphQLRateLimit struct {
+	Cost      int       // Cost of the most recent query
+	TotalCost int       // Cumulative cost since the client was created
+	Remaining int       // Points left in the current window
+	ResetAt   time.Time // When the window resets
 }
 
 // NewGraphQLClient creates a new GraphQL client
@@ -46,6 +63,10 @@ type GraphQLError struct {
 
 // doQuery executes a GraphQL query
 func (c *GraphQLClient) doQuery(ctx context.Context, query string, variables map[string]interface{}) (*GraphQLResponse, error) {
+	if err := c.checkRateLimit(); err != nil {
+		return nil, err
+	}
+
 	reqBody := GraphQLRequest{
 		Query:     query,
 		Variables: variables,
@@ -85,6 +106,8 @@ func (c *GraphQLClient) doQuery(ctx context.Context, query string, variables map
 		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
 
+	c.recordRateLimit(gqlResp.Data)
+
 	if len(gqlResp.Errors) > 0 {
 		return nil, fmt.Errorf("graphql errors: %v", gqlResp.Errors)
 	}
@@ -92,99 +115,358 @@ func (c *GraphQLClient) doQuery(ctx context.Context, query string, variables map
 	return &gqlResp, nil
 }
 
-// Discussion represents a GitHub discussion
+// RateLimit returns the most recently reported GraphQL rate limit state
+func (c *GraphQLClient) RateLimit() GraphQLRateLimit {
+	c.rateMu.Lock()
+	defer c.rateMu.Unlock()
+	return c.rate
+}
+
+// checkRateLimit refuses to spend the reserved budget before the window resets
+func (c *GraphQLClient) checkRateLimit() error {
+	c.rateMu.Lock()
+	defer c.rateMu.Unlock()
+
+	if c.rate.ResetAt.IsZero() || time.Now().After(c.rate.ResetAt) {
+		return nil
+	}
+	if c.rate.Remaining < minGraphQLRemaining {
+		return fmt.Errorf("graphql rate limit low (%d remaining), resets at: %s",
+			c.rate.Remaining, c.rate.ResetAt.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// recordRateLimit reads the rateLimit field every query selects
+func (c *GraphQLClient) recordRateLimit(data json.RawMessage) {
+	var result struct {
+		RateLimit *struct {
+			Cost      int       `json:"cost"`
+			Remaining int       `json:"remaining"`
+			ResetAt   time.Time `json:"resetAt"`
+		} `json:"rateLimit"`
+	}
+	if len(data) == 0 || json.Unmarshal(data, &result) != nil || result.RateLimit == nil {
+		return
+	}
+
+	c.rateMu.Lock()
+	c.rate.Cost = result.RateLimit.Cost
+	c.rate.TotalCost += result.RateLimit.Cost
+	c.rate.Remaining = result.RateLimit.Remaining
+	c.rate.ResetAt = result.RateLimit.ResetAt
+	c.rateMu.Unlock()
+}
+
+// Discussion represents a GitHub discussion.
+// ID is the global node ID; DatabaseID matches the REST API "id".
 type Discussion struct {
-	Number    int                 `json:"number"`
-	Title     string              `json:"title"`
-	Author    DiscussionAuthor    `json:"author"`
-	CreatedAt time.Time           `json:"createdAt"`
-	UpdatedAt time.Time           `json:"updatedAt"`
-	Comments  []DiscussionComment `json:"comments"`
-	Reactions []DiscussionReaction `json:"reactions"`
+	ID         string               `json:"id"`
+	DatabaseID int64                `json:"databaseId"`
+	Number     int                  `json:"number"`
+	Title      string               `json:"title"`
+	Author     DiscussionAuthor     `json:"author"`
+	CreatedAt  time.Time            `json:"createdAt"`
+	UpdatedAt  time.Time            `json:"updatedAt"`
+	Comments   []DiscussionComment  `json:"comments"`
+	Reactions  []DiscussionReaction `json:"reactions"`
 }
 
-// DiscussionAuthor represents a discussion author
+// DiscussionAuthor represents a discussion author.
+// DatabaseID is the REST user ID; zero for deleted accounts.
 type DiscussionAuthor struct {
-	Login string `json:"login"`
+	Login      string `json:"login"`
+	DatabaseID int64  `json:"databaseId,omitempty"`
 }
 
-// DiscussionComment represents a discussion comment
+// DiscussionComment represents a discussion comment or a reply to one.
+// Reactions and Replies are stored as their own events, not in the payload.
 type DiscussionComment struct {
-	Number    int              `json:"number"`
-	Body      string           `json:"body"`
-	Author    DiscussionAuthor `json:"author"`
-	CreatedAt time.Time        `json:"createdAt"`
-	IsAnswer  bool             `json:"isAnswer"`
+	ID         string           `json:"id"`
+	DatabaseID int64            `json:"databaseId"`
+	Number     int              `json:"number"`
+	Body       string           `json:"body"`
+	Author     DiscussionAuthor `json:"author"`
+	CreatedAt  time.Time        `json:"createdAt"`
+	IsAnswer   bool             `json:"isAnswer"`
+	ReplyToID  string           `json:"replyToId,omitempty"` // Parent comment node ID for replies
+
+	Reactions []DiscussionReaction `json:"-"`
+	Replies   []DiscussionComment  `json:"-"`
 }
 
 // DiscussionReaction represents a reaction on a discussion
 type DiscussionReaction struct {
-	Number    int              `json:"number"`
-	Content   string           `json:"content"`
-	User      DiscussionAuthor `json:"user"`
-	CreatedAt time.Time        `json:"createdAt"`
+	ID         string           `json:"id"`
+	DatabaseID int64            `json:"databaseId"`
+	Number     int              `json:"number"`
+	Content    string           `json:"content"`
+	User       DiscussionAuthor `json:"user"`
+	CreatedAt  time.Time        `json:"createdAt"`
 }
 
-// FetchDiscussions fetches discussions from a repository
-func (c *GraphQLClient) FetchDiscussions(ctx context.Context, owner, repo string) ([]Discussion, error) {
-	// GitHub GraphQL has a 500,000 node limit per query.
-	// 25 discussions × 50 comments × 50 reactions = 62,500 nodes (well under limit).
-	// Previous: 100 × 100 × 100 = 1,000,000 → MAX_NODE_LIMIT_EXCEEDED
-	query := `
-		query($owner: String!, $repo: String!, $first: Int!, $after: String) {
-			repository(owner: $owner, name: $repo) {
-				discussions(first: $first, after: $after, orderBy: {field: UPDATED_AT, direction: DESC}) {
+// Shared GraphQL fragments for discussion queries. GitHub rejects a query
+// that defines a fragment it doesn't spread, so each query appends exactly
+// the fragments it uses (including those nested in other fragments).
+const actorIdentityFragment = `
+	fragment actorIdentity on Actor {
+		login
+		... on User {
+			databaseId
+		}
+		... on Bot {
+			databaseId
+		}
+	}
+`
+
+const reactionFieldsFragment = `
+	fragment reactionFields on Reaction {
+		id
+		databaseId
+		content
+		user {
+			login
+			databaseId
+		}
+		createdAt
+	}
+`
+
+// commentFieldsFragment spreads actorIdentity
+const commentFieldsFragment = `
+	fragment commentFields on DiscussionComment {
+		id
+		databaseId
+		body
+		author {
+			...actorIdentity
+		}
+		createdAt
+		isAnswer
+	}
+`
+
+// commentWithChildrenFragment spreads commentFields and reactionFields
+const commentWithChildrenFragment = `
+	fragment commentWithChildren on DiscussionComment {
+		...commentFields
+		reactions(first: 50) {
+			pageInfo {
+				hasNextPage
+				endCursor
+			}
+			nodes {
+				...reactionFields
+			}
+		}
+		replies(first: 10) {
+			pageInfo {
+				hasNextPage
+				endCursor
+			}
+			nodes {
+				...commentFields
+				reactions(first: 10) {
 					pageInfo {
 						hasNextPage
 						endCursor
 					}
 					nodes {
-						number
-						title
-						author {
-							login
+						...reactionFields
+					}
+				}
+			}
+		}
+	}
+`
+
+// GitHub GraphQL has a 500,000 node limit per query. The first page inlines
+// 25 discussions × 50 comments × (50 reactions + 10 replies × 10 reactions)
+// ≈ 190,000 nodes. Anything beyond those first pages is fetched by follow-up
+// queries against the parent node ID.
+// Previous: 100 × 100 × 100 = 1,000,000 → MAX_NODE_LIMIT_EXCEEDED
+const discussionsQuery = `
+	query($owner: String!, $repo: String!, $first: Int!, $after: String) {
+		rateLimit {
+			cost
+			remaining
+			resetAt
+		}
+		repository(owner: $owner, name: $repo) {
+			discussions(first: $first, after: $after, orderBy: {field: UPDATED_AT, direction: DESC}) {
+				pageInfo {
+					hasNextPage
+					endCursor
+				}
+				nodes {
+					id
+					databaseId
+					number
+					title
+					author {
+						...actorIdentity
+					}
+					createdAt
+					updatedAt
+					reactions(first: 50) {
+						pageInfo {
+							hasNextPage
+							endCursor
+						}
+						nodes {
+							...reactionFields
+						}
+					}
+					comments(first: 50) {
+						pageInfo {
+							hasNextPage
+							endCursor
+						}
+						nodes {
+							...commentWithChildren
 						}
-						createdAt
-						updatedAt
+					}
+				}
+			}
+		}
+	}
+` + commentWithChildrenFragment + commentFieldsFragment + actorIdentityFragment + reactionFieldsFragment
+
+// discussionCommentsQuery pages a discussion's top-level comments
+const discussionCommentsQuery = `
+	query($id: ID!, $after: String) {
+		rateLimit {
+			cost
+			remaining
+			resetAt
+		}
+		node(id: $id) {
+			... on Discussion {
+				comments(first: 50, after: $after) {
+					pageInfo {
+						hasNextPage
+						endCursor
+					}
+					nodes {
+						...commentWithChildren
+					}
+				}
+			}
+		}
+	}
+` + commentWithChildrenFragment + commentFieldsFragment + actorIdentityFragment + reactionFieldsFragment
+
+// commentRepliesQuery pages a comment's replies
+const commentRepliesQuery = `
+	query($id: ID!, $after: String) {
+		rateLimit {
+			cost
+			remaining
+			resetAt
+		}
+		node(id: $id) {
+			... on DiscussionComment {
+				replies(first: 50, after: $after) {
+					pageInfo {
+						hasNextPage
+						endCursor
+					}
+					nodes {
+						...commentFields
 						reactions(first: 50) {
-							nodes {
-								content
-								user {
-									login
-								}
-								createdAt
+							pageInfo {
+								hasNextPage
+								endCursor
 							}
-						}
-						comments(first: 50) {
 							nodes {
-								body
-								author {
-									login
-								}
-								createdAt
-								isAnswer
-								reactions(first: 50) {
-									nodes {
-										content
-										user {
-											login
-										}
-										createdAt
-									}
-								}
+								...reactionFields
 							}
 						}
 					}
 				}
 			}
 		}
-	`
+	}
+` + commentFieldsFragment + actorIdentityFragment + reactionFieldsFragment
+
+// reactionsQuery pages reactions on any reactable node (discussion, comment, reply)
+const reactionsQuery = `
+	query($id: ID!, $after: String) {
+		rateLimit {
+			cost
+			remaining
+			resetAt
+		}
+		node(id: $id) {
+			... on Reactable {
+				reactions(first: 100, after: $after) {
+					pageInfo {
+						hasNextPage
+						endCursor
+					}
+					nodes {
+						...reactionFields
+					}
+				}
+			}
+		}
+	}
+` + reactionFieldsFragment
+
+// graphqlPageInfo is a connection's pagination state
+type graphqlPageInfo struct {
+	HasNextPage bool   `json:"hasNextPage"`
+	EndCursor   string `json:"endCursor"`
+}
+
+type reactionConnection struct {
+	PageInfo graphqlPageInfo      `json:"pageInfo"`
+	Nodes    []DiscussionReaction `json:"nodes"`
+}
+
+type commentConnection struct {
+	PageInfo graphqlPageInfo `json:"pageInfo"`
+	Nodes    []commentNode   `json:"nodes"`
+}
 
+// commentNode is a comment as returned by GraphQL, with its first pages of children
+type commentNode struct {
+	ID         string             `json:"id"`
+	DatabaseID int64              `json:"databaseId"`
+	Body       string             `json:"body"`
+	Author     DiscussionAuthor   `json:"author"`
+	CreatedAt  time.Time          `json:"createdAt"`
+	IsAnswer   bool               `json:"isAnswer"`
+	Reactions  reactionConnection `json:"reactions"`
+	Replies    *commentConnection `json:"replies"` // nil for replies themselves
+}
+
+// discussionNode is a discussion as returned by GraphQL, with its first pages of children
+type discussionNode struct {
+	ID         string             `json:"id"`
+	DatabaseID int64              `json:"databaseId"`
+	Number     int                `json:"number"`
+	Title      string             `json:"title"`
+	Author     DiscussionAuthor   `json:"author"`
+	CreatedAt  time.Time          `json:"createdAt"`
+	UpdatedAt  time.Time          `json:"updatedAt"`
+	Reactions  reactionConnection `json:"reactions"`
+	Comments   commentConnection  `json:"comments"`
+}
+
+// FetchDiscussions fetches discussions from a repository with complete
+// comment, reply and reaction threads. Connections with more items than the
+// first page are followed with per-node queries.
+//
+// Discussions are walked newest-updated first; pagination stops at the first
+// discussion updated before since. A zero since fetches everything.
+// On error, returns the discussions completed so far along with the error.
+func (c *GraphQLClient) FetchDiscussions(ctx context.Context, owner, repo string, since time.Time) ([]Discussion, error) {
 	var allDiscussions []Discussion
 	var cursor *string
 
-	// Paginate through all discussions (25 per page, max 10 pages = 250 discussions)
-	for page := 0; page < 10; page++ {
+	for {
 		variables := map[string]interface{}{
 			"owner": owner,
 			"repo":  repo,
@@ -194,105 +476,33 @@ func (c *GraphQLClient) FetchDiscussions(ctx context.Context, owner, repo string
 			variables["after"] = *cursor
 		}
 
-		resp, err := c.doQuery(ctx, query, variables)
+		resp, err := c.doQuery(ctx, discussionsQuery, variables)
 		if err != nil {
-			if len(allDiscussions) > 0 {
-				return allDiscussions, nil // Return partial results
-			}
-			return nil, err
+			return allDiscussions, err
 		}
 
-		// Parse response
 		var result struct {
 			Repository struct {
 				Discussions struct {
-					PageInfo struct {
-						HasNextPage bool   `json:"hasNextPage"`
-						EndCursor   string `json:"endCursor"`
-					} `json:"pageInfo"`
-					Nodes []struct {
-						Number    int       `json:"number"`
-						Title     string    `json:"title"`
-						Author    struct {
-							Login string `json:"login"`
-						} `json:"author"`
-						CreatedAt time.Time `json:"createdAt"`
-						UpdatedAt time.Time `json:"updatedAt"`
-						Reactions struct {
-							Nodes []struct {
-								Content   string    `json:"content"`
-								User      struct {
-									Login string `json:"login"`
-								} `json:"user"`
-								CreatedAt time.Time `json:"createdAt"`
-							} `json:"nodes"`
-						} `json:"reactions"`
-						Comments struct {
-							Nodes []struct {
-								Body      string    `json:"body"`
-								Author    struct {
-									Login string `json:"login"`
-								} `json:"author"`
-								CreatedAt time.Time `json:"createdAt"`
-								IsAnswer  bool      `json:"isAnswer"`
-								Reactions struct {
-									Nodes []struct {
-										Content   string    `json:"content"`
-										User      struct {
-											Login string `json:"login"`
-										} `json:"user"`
-										CreatedAt time.Time `json:"createdAt"`
-									} `json:"nodes"`
-								} `json:"reactions"`
-							} `json:"nodes"`
-						} `json:"comments"`
-					} `json:"nodes"`
+					PageInfo graphqlPageInfo  `json:"pageInfo"`
+					Nodes    []discussionNode `json:"nodes"`
 				} `json:"discussions"`
 			} `json:"repository"`
 		}
-
 		if err := json.Unmarshal(resp.Data, &result); err != nil {
 			return allDiscussions, fmt.Errorf("failed to parse discussions: %w", err)
 		}
 
 		for _, node := range result.Repository.Discussions.Nodes {
-			discussion := Discussion{
-				Number: node.Number,
-				Title:  node.Title,
-				Author: DiscussionAuthor{
-					Login: node.Author.Login,
-				},
-				CreatedAt: node.CreatedAt,
-				UpdatedAt: node.UpdatedAt,
-				Comments:  make([]DiscussionComment, 0, len(node.Comments.Nodes)),
-				Reactions: make([]DiscussionReaction, 0, len(node.Reactions.Nodes)),
+			if node.UpdatedAt.Before(since) {
+				return allDiscussions, nil // Everything after this is older still
 			}
 
-			for i, commentNode := range node.Comments.Nodes {
-				comment := DiscussionComment{
-					Number: i + 1,
-					Body:   commentNode.Body,
-					Author: DiscussionAuthor{
-						Login: commentNode.Author.Login,
-					},
-					CreatedAt: commentNode.CreatedAt,
-					IsAnswer:  commentNode.IsAnswer,
-				}
-				discussion.Comments = append(discussion.Comments, comment)
+			discussion, err := c.completeDiscussion(ctx, node)
+			if err != nil {
+				// Only whole threads are returned, never a truncated one
+				return allDiscussions, fmt.Errorf("failed to complete discussion #%d: %w", node.Number, err)
 			}
-
-			for i, reactionNode := range node.Reactions.Nodes {
-				reaction := DiscussionReaction{
-					Number:  i + 1,
-					Content: reactionNode.Content,
-					User: DiscussionAuthor{
-						Login: reactionNode.User.Login,
-					},
-					CreatedAt: reactionNode.CreatedAt,
-				}
-				discussion.Reactions = append(discussion.Reactions, reaction)
-			}
-
 			allDiscussions = append(allDiscussions, discussion)
 		}
 
@@ -305,3 +515,143 @@ func (c *GraphQLClient) FetchDiscussions(ctx context.Context, owner, repo string
 
 	return allDiscussions, nil
 }
+
+// completeDiscussion follows every truncated connection under a discussion
+func (c *GraphQLClient) completeDiscussion(ctx context.Context, node discussionNode) (Discussion, error) {
+	discussion := Discussion{
+		ID:         node.ID,
+		DatabaseID: node.DatabaseID,
+		Number:     node.Number,
+		Title:      node.Title,
+		Author:     node.Author,
+		CreatedAt:  node.CreatedAt,
+		UpdatedAt:  node.UpdatedAt,
+	}
+
+	reactions, err := c.completeReactions(ctx, node.ID, node.Reactions)
+	if err != nil {
+		return discussion, err
+	}
+	discussion.Reactions = reactions
+
+	comments := node.Comments
+	for {
+		for _, commentNode := range comments.Nodes {
+			comment, err := c.completeComment(ctx, commentNode, "")
+			if err != nil {
+				return discussion, err
+			}
+			comment.Number = len(discussion.Comments) + 1
+			discussion.Comments = append(discussion.Comments, comment)
+		}
+
+		if !comments.PageInfo.HasNextPage {
+			break
+		}
+
+		var result struct {
+			Node struct {
+				Comments commentConnection `json:"comments"`
+			} `json:"node"`
+		}
+		if err := c.queryNode(ctx, discussionCommentsQuery, node.ID, comments.PageInfo.EndCursor, &result); err != nil {
+			return discussion, fmt.Errorf("failed to fetch comments: %w", err)
+		}
+		comments = result.Node.Comments
+	}
+
+	return discussion, nil
+}
+
+// completeComment follows a comment's truncated reactions and replies.
+// parentID is the parent comment's node ID for replies, empty otherwise.
+func (c *GraphQLClient) completeComment(ctx context.Context, node commentNode, parentID string) (DiscussionComment, error) {
+	comment := DiscussionComment{
+		ID:         node.ID,
+		DatabaseID: node.DatabaseID,
+		Body:       node.Body,
+		Author:     node.Author,
+		CreatedAt:  node.CreatedAt,
+		IsAnswer:   node.IsAnswer,
+		ReplyToID:  parentID,
+	}
+
+	reactions, err := c.completeReactions(ctx, node.ID, node.Reactions)
+	if err != nil {
+		return comment, err
+	}
+	comment.Reactions = reactions
+
+	if node.Replies == nil {
+		return comment, nil // Replies can't have replies
+	}
+
+	replies := *node.Replies
+	for {
+		for _, replyNode := range replies.Nodes {
+			reply, err := c.completeComment(ctx, replyNode, node.ID)
+			if err != nil {
+				return comment, err
+			}
+			reply.Number = len(comment.Replies) + 1
+			comment.Replies = append(comment.Replies, reply)
+		}
+
+		if !replies.PageInfo.HasNextPage {
+			break
+		}
+
+		var result struct {
+			Node struct {
+				Replies commentConnection `json:"replies"`
+			} `json:"node"`
+		}
+		if err := c.queryNode(ctx, commentRepliesQuery, node.ID, replies.PageInfo.EndCursor, &result); err != nil {
+			return comment, fmt.Errorf("failed to fetch replies: %w", err)
+		}
+		replies = result.Node.Replies
+	}
+
+	return comment, nil
+}
+
+// completeReactions returns all reactions on a node, starting from its first page
+func (c *GraphQLClient) completeReactions(ctx context.Context, nodeID string, first reactionConnection) ([]DiscussionReaction, error) {
+	reactions := make([]DiscussionReaction, 0, len(first.Nodes))
+	page := first
+	for {
+		for _, reaction := range page.Nodes {
+			reaction.Number = len(reactions) + 1
+			reactions = append(reactions, reaction)
+		}
+
+		if !page.PageInfo.HasNextPage {
+			return reactions, nil
+		}
+
+		var result struct {
+			Node struct {
+				Reactions reactionConnection `json:"reactions"`
+			} `json:"node"`
+		}
+		if err := c.queryNode(ctx, reactionsQuery, nodeID, page.PageInfo.EndCursor, &result); err != nil {
+			return reactions, fmt.Errorf("failed to fetch reactions: %w", err)
+		}
+		page = result.Node.Reactions
+	}
+}
+
+// queryNode runs a follow-up page query for a single node and decodes it into target
+func (c *GraphQLClient) queryNode(ctx context.Context, query, nodeID, after string, target interface{}) error {
+	resp, err := c.doQuery(ctx, query, map[string]interface{}{
+		"id":    nodeID,
+		"after": after,
+	})
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(resp.Data, target); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	return nil
+}
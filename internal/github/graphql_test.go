@@ -0,0 +1,47 @@
+package github
+
+import (
+	"regexp"
+	"testing"
+)
+
+var (
+	fragmentDefinition = regexp.MustCompile(`fragment (\w+) on`)
+	fragmentSpread     = regexp.MustCompile(`\.\.\.(\w+)`)
+)
+
+// GitHub rejects queries with unused or undefined fragments
+func TestQueryFragments(t *testing.T) {
+	queries := map[string]string{
+		"discussionsQuery":        discussionsQuery,
+		"discussionCommentsQuery": discussionCommentsQuery,
+		"commentRepliesQuery":     commentRepliesQuery,
+		"reactionsQuery":          reactionsQuery,
+	}
+
+	for name, query := range queries {
+		defined := map[string]bool{}
+		for _, m := range fragmentDefinition.FindAllStringSubmatch(query, -1) {
+			if defined[m[1]] {
+				t.Errorf("%s defines fragment %s twice", name, m[1])
+			}
+			defined[m[1]] = true
+		}
+
+		spread := map[string]bool{}
+		for _, m := range fragmentSpread.FindAllStringSubmatch(query, -1) {
+			spread[m[1]] = true
+		}
+
+		for fragment := range defined {
+			if !spread[fragment] {
+				t.Errorf("%s defines fragment %s but never spreads it", name, fragment)
+			}
+		}
+		for fragment := range spread {
+			if !defined[fragment] {
+				t.Errorf("%s spreads fragment %s but doesn't define it", name, fragment)
+			}
+		}
+	}
+}
@@ -38,6 +38,11 @@ export function EditHistory({ entries }: { entries: EditHistoryEntry[] }) {
                 {" \u00B7 "}
                 {timeAgo(entry.editedAt)}
               </div>
+              {entry.title && (
+                <div className="text-xs text-zinc-400 font-medium break-words">
+                  {entry.title}
+                </div>
+              )}
               <div className="text-xs text-zinc-500 whitespace-pre-line break-words line-clamp-4">
                 {entry.body}
               </div>
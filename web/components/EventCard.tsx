@@ -10,16 +10,32 @@ const EVENT_CONFIG: Record<string, { icon: string; color: string; label: string
   pr_reopened: { icon: "\u25D0", color: "text-blue-300", label: "PR reopened" },
   review_submitted: { icon: "\u2714", color: "text-green-400", label: "Review" },
   review_comment: { icon: "\u{1F4AC}", color: "text-zinc-400", label: "Review comment" },
+  review_dismissed: { icon: "\u2716", color: "text-zinc-500", label: "Review dismissed" },
+  review_edited: { icon: "\u270E", color: "text-zinc-500", label: "Review edited" },
+  pr_labeled: { icon: "\u25C6", color: "text-zinc-400", label: "PR labeled" },
+  pr_assigned: { icon: "\u25C6", color: "text-zinc-400", label: "PR assigned" },
+  pr_review_requested: { icon: "\u25C6", color: "text-zinc-400", label: "Review requested" },
+  pr_converted_to_draft: { icon: "\u25CC", color: "text-zinc-500", label: "PR marked draft" },
+  pr_ready_for_review: { icon: "\u25D0", color: "text-blue-300", label: "PR ready for review" },
   issue_opened: { icon: "\u25EF", color: "text-yellow-400", label: "Issue opened" },
   issue_closed: { icon: "\u25C9", color: "text-zinc-500", label: "Issue closed" },
+  issue_labeled: { icon: "\u25C6", color: "text-zinc-400", label: "Issue labeled" },
+  issue_locked: { icon: "\u25A3", color: "text-zinc-500", label: "Issue locked" },
+  issue_transferred: { icon: "\u21AA", color: "text-zinc-500", label: "Issue transferred" },
   issue_comment: { icon: "\u25B8", color: "text-zinc-400", label: "Comment" },
   comment: { icon: "\u25B8", color: "text-zinc-400", label: "Comment" },
+  comment_deleted: { icon: "\u2715", color: "text-zinc-500", label: "Comment deleted" },
   reaction: { icon: "\u26A1", color: "text-amber-400", label: "Reaction" },
+  reaction_removed: { icon: "\u21BA", color: "text-zinc-500", label: "Reaction removed" },
   star: { icon: "\u2605", color: "text-yellow-400", label: "Starred" },
   fork: { icon: "\u2442", color: "text-cyan-400", label: "Forked" },
   discussion_created: { icon: "\u25C8", color: "text-indigo-400", label: "Discussion" },
   discussion_comment: { icon: "\u25C7", color: "text-indigo-300", label: "Discussion comment" },
   discussion_answered: { icon: "\u2713", color: "text-green-400", label: "Discussion answered" },
+  discussion_unanswered: { icon: "\u2717", color: "text-zinc-500", label: "Answer unmarked" },
+  discussion_closed: { icon: "\u25C9", color: "text-zinc-500", label: "Discussion closed" },
+  discussion_locked: { icon: "\u25A3", color: "text-zinc-500", label: "Discussion locked" },
+  discussion_category_changed: { icon: "\u21C4", color: "text-zinc-500", label: "Discussion moved" },
 };
 
 const REACTION_EMOJI: Record<string, string> = {
@@ -54,7 +70,7 @@ const GITHUB_REPO = "https://github.com/skridlevsky/openchaos";
 // Comment-type events show the parent PR/issue title inline instead of on a separate line
 const COMMENT_TYPES = new Set([
   "issue_comment", "review_comment", "review_submitted",
-  "commit_comment", "discussion_comment",
+  "commit_comment", "discussion_comment", "comment_deleted",
 ]);
 
 export function EventCard({ event }: { event: FeedEvent }) {
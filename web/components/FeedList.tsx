@@ -19,6 +19,7 @@ const EVENT_FILTERS = [
 const SORT_OPTIONS = [
   { label: "Newest", value: "newest" },
   { label: "Oldest", value: "oldest" },
+  { label: "Relevant", value: "relevance" },
 ];
 
 const API_URL = process.env.NEXT_PUBLIC_API_URL || "";
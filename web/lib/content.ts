@@ -27,6 +27,32 @@ export function extractContent(
       return { title: str(issue?.title), body: str(issue?.body) };
     }
 
+    // Deleted comment tombstone - parent title as context, no body
+    case "comment_deleted": {
+      const issue = payload.issue as Record<string, unknown> | undefined;
+      const pr = payload.pull_request as Record<string, unknown> | undefined;
+      return { title: str(issue?.title) || str(pr?.title) };
+    }
+
+    // PR/issue triage - title only, the label/assignee is in the payload
+    case "pr_labeled":
+    case "pr_assigned":
+    case "pr_review_requested":
+    case "pr_converted_to_draft":
+    case "pr_ready_for_review": {
+      const pr = payload.pull_request as Record<string, unknown> | undefined;
+      const label = payload.label as Record<string, unknown> | undefined;
+      const assignee = (payload.assignee || payload.requested_reviewer) as Record<string, unknown> | undefined;
+      return { title: str(pr?.title), body: str(label?.name) || str(assignee?.login) };
+    }
+    case "issue_labeled":
+    case "issue_locked":
+    case "issue_transferred": {
+      const issue = payload.issue as Record<string, unknown> | undefined;
+      const label = payload.label as Record<string, unknown> | undefined;
+      return { title: str(issue?.title), body: str(label?.name) };
+    }
+
     // Issue comment - issue title as context, comment body
     case "issue_comment": {
       const issue = payload.issue as Record<string, unknown> | undefined;
@@ -35,7 +61,9 @@ export function extractContent(
     }
 
     // Review - PR title as context, review body
-    case "review_submitted": {
+    case "review_submitted":
+    case "review_dismissed":
+    case "review_edited": {
       const pr = payload.pull_request as Record<string, unknown> | undefined;
       const review = payload.review as Record<string, unknown> | undefined;
       return { title: str(pr?.title), body: str(review?.body) };
@@ -65,6 +93,16 @@ export function extractContent(
       return { title: str(disc?.title), body: str(disc?.body) };
     }
 
+    // Discussion state changes - title only (webhook shape)
+    case "discussion_answered":
+    case "discussion_unanswered":
+    case "discussion_closed":
+    case "discussion_locked":
+    case "discussion_category_changed": {
+      const disc = payload.discussion as Record<string, unknown> | undefined;
+      return { title: str(disc?.title) };
+    }
+
     // Discussion comment - GraphQL shape: {body, ...} (flat)
     case "discussion_comment": {
       return { body: str(payload.body) };
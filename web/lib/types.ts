@@ -1,4 +1,5 @@
 export interface EditHistoryEntry {
+  title?: string; // PRs and issues only
   body: string;
   editedAt: string;
 }
@@ -18,7 +19,11 @@ export interface FeedEvent {
   payload?: Record<string, unknown>;
   contentHash: string;
   editHistory?: EditHistoryEntry[];
+  relatedEventId?: string;
+  retractedAt?: string;
+  deletedAt?: string;
   reactionSummary?: Record<string, number>;
+  relevanceScore?: number;
   occurredAt: string;
   ingestedAt: string;
 }
@@ -48,6 +53,19 @@ export interface VoterSummary {
   lastVote: string;
   prsVotedOn: number[];
   uniquePrs: number;
+  flips: number;
+  profile?: UserProfile;
+  aliases?: string[];
+}
+
+export interface UserProfile {
+  type?: "User" | "Bot" | "Organization";
+  accountCreatedAt?: string;
+  accountAgeDays?: number;
+  followers: number;
+  publicRepos: number;
+  notFound?: boolean;
+  fetchedAt: string;
 }
 
 export interface PRVotesResponse {
@@ -56,10 +74,21 @@ export interface PRVotesResponse {
   downvotes: number;
   net: number;
   voters: VoterVoteDetail[];
+  byRelationship: Record<VoterRelationship, VoteTally>;
+  excludingAuthor: VoteTally;
+}
+
+export type VoterRelationship = "author" | "reviewer" | "commenter" | "none";
+
+export interface VoteTally {
+  upvotes: number;
+  downvotes: number;
+  net: number;
 }
 
 export interface VoterVoteDetail {
   githubUser: string;
   choice: number;
   votedAt: string;
+  relationship: VoterRelationship;
 }